@@ -1,12 +1,21 @@
 package dtrack
 
 import (
+	"cmp"
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 
 	"github.com/google/uuid"
+
+	"github.com/futurice/dependency-track-client-go/cyclonedx"
+	"github.com/futurice/dependency-track-client-go/spdx"
 )
 
 type BOMService struct {
@@ -24,11 +33,80 @@ type BOMUploadRequest struct {
 	BOM            string     `json:"bom"`
 }
 
-type bomUploadResponse struct {
-	Token BOMUploadToken `json:"token"`
+func (ur BOMUploadRequest) hasParent() bool {
+	return ur.metadata().hasParent()
+}
+
+func (ur BOMUploadRequest) metadata() BOMUploadMetadata {
+	return BOMUploadMetadata{
+		ProjectUUID:    ur.ProjectUUID,
+		ProjectName:    ur.ProjectName,
+		ProjectVersion: ur.ProjectVersion,
+		ParentUUID:     ur.ParentUUID,
+		ParentName:     ur.ParentName,
+		ParentVersion:  ur.ParentVersion,
+		AutoCreate:     ur.AutoCreate,
+	}
+}
+
+// BOMUploadMetadata identifies the project a streamed BOM is uploaded to.
+type BOMUploadMetadata struct {
+	ProjectUUID    *uuid.UUID
+	ProjectName    string
+	ProjectVersion string
+	ParentUUID     *uuid.UUID // Since v4.8.0
+	ParentName     string     // Since v4.8.0
+	ParentVersion  string     // Since v4.8.0
+	AutoCreate     bool
+}
+
+func (m BOMUploadMetadata) hasParent() bool {
+	return m.ParentUUID != nil || m.ParentName != "" || m.ParentVersion != ""
+}
+
+func (m BOMUploadMetadata) formValues() url.Values {
+	params := make(url.Values)
+	if m.ProjectUUID != nil {
+		params["project"] = append(params["project"], m.ProjectUUID.String())
+	}
+	if m.AutoCreate {
+		params["autoCreate"] = append(params["autoCreate"], "true")
+	}
+	if m.ProjectName != "" {
+		params["projectName"] = append(params["projectName"], m.ProjectName)
+	}
+	if m.ProjectVersion != "" {
+		params["projectVersion"] = append(params["projectVersion"], m.ProjectVersion)
+	}
+	if m.ParentUUID != nil {
+		params["parentUUID"] = append(params["parentUUID"], m.ParentUUID.String())
+	}
+	if m.ParentName != "" {
+		params["parentName"] = append(params["parentName"], m.ParentName)
+	}
+	if m.ParentVersion != "" {
+		params["parentVersion"] = append(params["parentVersion"], m.ParentVersion)
+	}
+
+	return params
+}
+
+// uploadRequest returns a request uploading bom to the project identified by m.
+func (m BOMUploadMetadata) uploadRequest(bom string) BOMUploadRequest {
+	return BOMUploadRequest{
+		ProjectUUID:    m.ProjectUUID,
+		ProjectName:    m.ProjectName,
+		ProjectVersion: m.ProjectVersion,
+		ParentUUID:     m.ParentUUID,
+		ParentName:     m.ParentName,
+		ParentVersion:  m.ParentVersion,
+		AutoCreate:     m.AutoCreate,
+		BOM:            bom,
+	}
 }
 
-type BOMUploadToken string
+// BOMUploadToken identifies the processing of an uploaded BOM.
+type BOMUploadToken = EventToken
 
 type BOMFormat string
 
@@ -37,6 +115,15 @@ const (
 	BOMFormatXML  BOMFormat = "XML"
 )
 
+// mediaType returns the media type to accept when exporting BOMs in format f.
+func (f BOMFormat) mediaType() string {
+	if f == BOMFormatXML {
+		return "application/vnd.cyclonedx+xml"
+	}
+
+	return "application/vnd.cyclonedx+json"
+}
+
 type BOMVariant string
 
 const (
@@ -46,23 +133,84 @@ const (
 )
 
 func (bs BOMService) ExportComponent(ctx context.Context, componentUUID uuid.UUID, format BOMFormat) (bom string, err error) {
+	req, err := bs.newComponentExportRequest(ctx, "BOM.ExportComponent", componentUUID, format)
+	if err != nil {
+		return
+	}
+
+	_, err = bs.client.doRequest(req, &bom)
+	return
+}
+
+// ExportComponentBOM exports the BOM of a component, and parses it.
+func (bs BOMService) ExportComponentBOM(ctx context.Context, componentUUID uuid.UUID) (bom *cyclonedx.BOM, err error) {
+	req, err := bs.newComponentExportRequest(ctx, "BOM.ExportComponentBOM", componentUUID, BOMFormatJSON)
+	if err != nil {
+		return
+	}
+
+	bom = new(cyclonedx.BOM)
+	_, err = bs.client.doRequest(req, bom)
+	return
+}
+
+// ExportComponentTo exports the BOM of a component, and writes it to w without buffering it in memory.
+func (bs BOMService) ExportComponentTo(ctx context.Context, componentUUID uuid.UUID, format BOMFormat, w io.Writer) (err error) {
+	req, err := bs.newComponentExportRequest(ctx, "BOM.ExportComponentTo", componentUUID, format)
+	if err != nil {
+		return
+	}
+
+	_, err = bs.client.doRequest(req, w)
+	return
+}
+
+func (bs BOMService) newComponentExportRequest(ctx context.Context, operationName string, componentUUID uuid.UUID, format BOMFormat) (*http.Request, error) {
 	params := make(map[string]string)
 	if format != "" {
 		params["format"] = string(format)
 	}
 
-	req, err := bs.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/bom/cyclonedx/component/%s", componentUUID), withParams(params))
+	return bs.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/bom/cyclonedx/component/%s", componentUUID),
+		withOperationName(operationName), withParams(params), withAcceptContentType(format.mediaType()))
+}
+
+func (bs BOMService) ExportProject(ctx context.Context, projectUUID uuid.UUID, format BOMFormat, variant BOMVariant) (bom string, err error) {
+	req, err := bs.newProjectExportRequest(ctx, "BOM.ExportProject", projectUUID, format, variant)
 	if err != nil {
 		return
 	}
 
-	req.Header.Set("Accept", "application/vnd.cyclonedx+json")
-
 	_, err = bs.client.doRequest(req, &bom)
 	return
 }
 
-func (bs BOMService) ExportProject(ctx context.Context, projectUUID uuid.UUID, format BOMFormat, variant BOMVariant) (bom string, err error) {
+// ExportProjectBOM exports the BOM of a project, and parses it.
+// For BOMVariantVDR and BOMVariantWithVulnerabilities, the BOM includes vulnerabilities,
+// and for the latter also their analyses.
+func (bs BOMService) ExportProjectBOM(ctx context.Context, projectUUID uuid.UUID, variant BOMVariant) (bom *cyclonedx.BOM, err error) {
+	req, err := bs.newProjectExportRequest(ctx, "BOM.ExportProjectBOM", projectUUID, BOMFormatJSON, variant)
+	if err != nil {
+		return
+	}
+
+	bom = new(cyclonedx.BOM)
+	_, err = bs.client.doRequest(req, bom)
+	return
+}
+
+// ExportProjectTo exports the BOM of a project, and writes it to w without buffering it in memory.
+func (bs BOMService) ExportProjectTo(ctx context.Context, projectUUID uuid.UUID, format BOMFormat, variant BOMVariant, w io.Writer) (err error) {
+	req, err := bs.newProjectExportRequest(ctx, "BOM.ExportProjectTo", projectUUID, format, variant)
+	if err != nil {
+		return
+	}
+
+	_, err = bs.client.doRequest(req, w)
+	return
+}
+
+func (bs BOMService) newProjectExportRequest(ctx context.Context, operationName string, projectUUID uuid.UUID, format BOMFormat, variant BOMVariant) (*http.Request, error) {
 	params := make(map[string]string)
 	if format != "" {
 		params["format"] = string(format)
@@ -70,25 +218,64 @@ func (bs BOMService) ExportProject(ctx context.Context, projectUUID uuid.UUID, f
 	if variant != "" {
 		params["variant"] = string(variant)
 	}
+	if variant == BOMVariantVDR {
+		if err := bs.client.requireFeature(ctx, FeatureBOMVariantVDR); err != nil {
+			return nil, err
+		}
+	}
 
-	req, err := bs.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/bom/cyclonedx/project/%s", projectUUID), withParams(params))
+	return bs.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/bom/cyclonedx/project/%s", projectUUID),
+		withOperationName(operationName), withParams(params), withAcceptContentType(format.mediaType()))
+}
+
+func (bs BOMService) Upload(ctx context.Context, uploadReq BOMUploadRequest) (token BOMUploadToken, err error) {
+	if uploadReq.hasParent() {
+		if err = bs.client.requireFeature(ctx, FeatureBOMUploadParent); err != nil {
+			return
+		}
+	}
+
+	if err = bs.client.validateBOM(uploadReq.BOM, true); err != nil {
+		return
+	}
+
+	req, err := bs.client.newRequest(ctx, http.MethodPut, "/api/v1/bom", withBody(uploadReq))
 	if err != nil {
 		return
 	}
 
-	req.Header.Set("Accept", "application/vnd.cyclonedx+json")
+	var uploadRes eventTokenResponse
+	_, err = bs.client.doRequest(req, &uploadRes)
+	if err != nil {
+		return
+	}
 
-	_, err = bs.client.doRequest(req, &bom)
+	token = uploadRes.Token
 	return
 }
 
-func (bs BOMService) Upload(ctx context.Context, uploadReq BOMUploadRequest) (token BOMUploadToken, err error) {
-	req, err := bs.client.newRequest(ctx, http.MethodPut, "/api/v1/bom", withBody(uploadReq))
+func (bs BOMService) PostBom(ctx context.Context, uploadReq BOMUploadRequest) (token BOMUploadToken, err error) {
+	if uploadReq.hasParent() {
+		if err = bs.client.requireFeature(ctx, FeatureBOMUploadParent); err != nil {
+			return
+		}
+	}
+
+	if err = bs.client.validateBOM(uploadReq.BOM, false); err != nil {
+		return
+	}
+
+	params := uploadReq.metadata().formValues()
+	if uploadReq.BOM != "" {
+		params["bom"] = append(params["bom"], uploadReq.BOM)
+	}
+
+	req, err := bs.client.newRequest(ctx, http.MethodPost, "/api/v1/bom", withMultiPart(params))
 	if err != nil {
 		return
 	}
 
-	var uploadRes bomUploadResponse
+	var uploadRes eventTokenResponse
 	_, err = bs.client.doRequest(req, &uploadRes)
 	if err != nil {
 		return
@@ -98,39 +285,270 @@ func (bs BOMService) Upload(ctx context.Context, uploadReq BOMUploadRequest) (to
 	return
 }
 
-func (bs BOMService) PostBom(ctx context.Context, uploadReq BOMUploadRequest) (token BOMUploadToken, err error) {
-	params := make(url.Values)
-	if uploadReq.ProjectUUID != nil {
-		params["project"] = append(params["project"], uploadReq.ProjectUUID.String())
+// UploadSPDX converts an SPDX 2.2 or 2.3 document in JSON or tag-value format to CycloneDX,
+// and uploads the result using Upload. The returned report lists the fields of the document
+// that could not be converted. It is returned even if the upload fails.
+func (bs BOMService) UploadSPDX(ctx context.Context, meta BOMUploadMetadata, document []byte) (token BOMUploadToken, report spdx.ConversionReport, err error) {
+	doc, _, err := spdx.Parse(document)
+	if err != nil {
+		err = fmt.Errorf("failed to parse spdx document: %w", err)
+		return
 	}
-	if uploadReq.AutoCreate {
-		params["autoCreate"] = append(params["autoCreate"], "true")
+
+	bom, report, err := spdx.Convert(doc)
+	if err != nil {
+		return
 	}
-	if uploadReq.ProjectName != "" {
-		params["projectName"] = append(params["projectName"], uploadReq.ProjectName)
+
+	content, err := cyclonedx.Marshal(bom, cyclonedx.FormatJSON)
+	if err != nil {
+		return
 	}
-	if uploadReq.ProjectVersion != "" {
-		params["projectVersion"] = append(params["projectVersion"], uploadReq.ProjectVersion)
+
+	token, err = bs.Upload(ctx, meta.uploadRequest(base64.StdEncoding.EncodeToString(content)))
+	return
+}
+
+// MergeMode determines how UploadMerged combines multiple BOMs.
+type MergeMode string
+
+const (
+	// MergeModeFlat merges all BOMs into a single BOM, which is uploaded to one project. See cyclonedx.Merge.
+	MergeModeFlat MergeMode = "flat"
+
+	// MergeModeHierarchical uploads each BOM to its own child project of a shared parent project.
+	MergeModeHierarchical MergeMode = "hierarchical"
+)
+
+// BOMPart is one of the BOMs uploaded using UploadMerged.
+type BOMPart struct {
+	Name    string // Name of the child project in MergeModeHierarchical
+	Version string // Version of the child project in MergeModeHierarchical. Defaults to the version of the parent.
+	BOM     []byte // CycloneDX document in JSON or XML format
+}
+
+type MergedUploadRequest struct {
+	Mode    MergeMode
+	Project BOMUploadMetadata // Project to upload to in MergeModeFlat, or the parent project in MergeModeHierarchical
+	Parts   []BOMPart
+}
+
+// UploadMerged uploads the BOMs of multiple parts of a product, e.g. of a backend, a frontend and a base image.
+//
+// In MergeModeFlat, the BOMs are merged into a single BOM, which is uploaded to Project, and a single token is returned.
+// Merging is lossy: only what the cyclonedx package models is retained, i.e. components, services, dependencies
+// and vulnerabilities, with metadata components becoming regular components. Everything else, e.g. metadata
+// tools, timestamps and properties, compositions, or component suppliers, is dropped.
+// In MergeModeHierarchical, the BOMs are uploaded unmodified, each to a child project of Project,
+// and one token per part is returned.
+// Child projects are created as needed. The parent project is created if it does not exist and AutoCreate is set.
+// When the upload of a part fails, the tokens of the parts uploaded before are returned along with the error.
+func (bs BOMService) UploadMerged(ctx context.Context, uploadReq MergedUploadRequest) (tokens []BOMUploadToken, err error) {
+	if len(uploadReq.Parts) == 0 {
+		err = fmt.Errorf("no bom parts to upload")
+		return
+	}
+
+	switch uploadReq.Mode {
+	case MergeModeFlat:
+		return bs.uploadFlat(ctx, uploadReq)
+	case MergeModeHierarchical:
+		return bs.uploadHierarchical(ctx, uploadReq)
+	default:
+		err = fmt.Errorf("invalid merge mode %q", uploadReq.Mode)
+		return
 	}
-	if uploadReq.ParentUUID != nil {
-		params["parentUUID"] = append(params["parentUUID"], uploadReq.ParentUUID.String())
+}
+
+func (bs BOMService) uploadFlat(ctx context.Context, uploadReq MergedUploadRequest) ([]BOMUploadToken, error) {
+	boms := make([]*cyclonedx.BOM, 0, len(uploadReq.Parts))
+	for i, part := range uploadReq.Parts {
+		bom, _, err := cyclonedx.Parse(part.BOM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse bom part %d: %w", i, err)
+		}
+		boms = append(boms, bom)
 	}
-	if uploadReq.ParentName != "" {
-		params["parentName"] = append(params["parentName"], uploadReq.ParentName)
+
+	content, err := cyclonedx.Marshal(cyclonedx.Merge(boms...), cyclonedx.FormatJSON)
+	if err != nil {
+		return nil, err
 	}
-	if uploadReq.ParentVersion != "" {
-		params["parentVersion"] = append(params["parentVersion"], uploadReq.ParentVersion)
+
+	token, err := bs.Upload(ctx, uploadReq.Project.uploadRequest(base64.StdEncoding.EncodeToString(content)))
+	if err != nil {
+		return nil, err
 	}
-	if uploadReq.BOM != "" {
-		params["bom"] = append(params["bom"], uploadReq.BOM)
+
+	return []BOMUploadToken{token}, nil
+}
+
+func (bs BOMService) uploadHierarchical(ctx context.Context, uploadReq MergedUploadRequest) ([]BOMUploadToken, error) {
+	for i, part := range uploadReq.Parts {
+		if part.Name == "" {
+			return nil, fmt.Errorf("bom part %d has no name", i)
+		}
 	}
 
-	req, err := bs.client.newRequest(ctx, http.MethodPost, "/api/v1/bom", withMultiPart(params))
+	// Fail before the parent project is created if child projects can't be created.
+	if err := bs.client.requireFeature(ctx, FeatureBOMUploadParent); err != nil {
+		return nil, err
+	}
+
+	parent := uploadReq.Project
+	meta := BOMUploadMetadata{AutoCreate: true}
+	switch {
+	case parent.ProjectUUID != nil:
+		meta.ParentUUID = parent.ProjectUUID
+	case parent.AutoCreate:
+		project, err := bs.lookupOrCreateParent(ctx, parent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create parent project: %w", err)
+		}
+		meta.ParentUUID = &project.UUID
+	default:
+		meta.ParentName = parent.ProjectName
+		meta.ParentVersion = parent.ProjectVersion
+	}
+
+	tokens := make([]BOMUploadToken, 0, len(uploadReq.Parts))
+	for _, part := range uploadReq.Parts {
+		meta.ProjectName = part.Name
+		meta.ProjectVersion = cmp.Or(part.Version, parent.ProjectVersion)
+
+		token, err := bs.Upload(ctx, meta.uploadRequest(base64.StdEncoding.EncodeToString(part.BOM)))
+		if err != nil {
+			return tokens, fmt.Errorf("failed to upload bom part %s: %w", part.Name, err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+// lookupOrCreateParent looks up the project identified by name and version of meta,
+// and creates it below the parent of meta if it does not exist.
+func (bs BOMService) lookupOrCreateParent(ctx context.Context, meta BOMUploadMetadata) (p Project, err error) {
+	p, err = bs.client.Project.Lookup(ctx, meta.ProjectName, meta.ProjectVersion)
+	if !errors.Is(err, ErrNotFound) {
+		return
+	}
+
+	project := Project{Name: meta.ProjectName, Version: meta.ProjectVersion, Active: true}
+	if meta.hasParent() {
+		parentUUID := meta.ParentUUID
+		if parentUUID == nil {
+			var grandparent Project
+			if grandparent, err = bs.client.Project.Lookup(ctx, meta.ParentName, meta.ParentVersion); err != nil {
+				return
+			}
+			parentUUID = &grandparent.UUID
+		}
+		project.ParentRef = &ParentRef{UUID: *parentUUID}
+	}
+
+	return bs.client.Project.Create(ctx, project)
+}
+
+// UploadOption configures streamed BOM uploads.
+type UploadOption func(*uploadConfig)
+
+type uploadConfig struct {
+	compress      bool
+	contentLength int64
+	onProgress    func(p UploadProgress)
+}
+
+// UploadProgress describes the progress of a streamed BOM upload.
+type UploadProgress struct {
+	BytesRead     int64 // Bytes of the BOM read so far, before compression
+	ContentLength int64 // Size of the BOM in bytes, or -1 if unknown
+}
+
+// WithGzip compresses the request body using gzip, and sets the Content-Encoding header accordingly.
+// The server, or a reverse proxy in front of it, must support compressed requests.
+func WithGzip() UploadOption {
+	return func(uc *uploadConfig) {
+		uc.compress = true
+	}
+}
+
+// WithContentLength sets the size of the BOM, which is reported in UploadProgress.
+// It is determined automatically for files, and for readers providing a Len method, e.g. *bytes.Reader.
+func WithContentLength(n int64) UploadOption {
+	return func(uc *uploadConfig) {
+		uc.contentLength = n
+	}
+}
+
+// WithUploadProgress registers a callback that is invoked as the BOM is being read.
+//
+// fn is called on the goroutine that streams the request body, not on the goroutine calling the upload method.
+// Calls never overlap, but fn must synchronize access to state it shares with other goroutines.
+// If the upload fails before the BOM was read completely, fn may be called once more after the upload method returned.
+func WithUploadProgress(fn func(p UploadProgress)) UploadOption {
+	return func(uc *uploadConfig) {
+		uc.onProgress = fn
+	}
+}
+
+// UploadReader uploads the BOM read from bom, without buffering it in memory.
+// The request body is streamed, hence the request is never retried.
+//
+// Large uploads may take longer than the timeout of the client, see WithTimeout.
+func (bs BOMService) UploadReader(ctx context.Context, meta BOMUploadMetadata, bom io.Reader, options ...UploadOption) (token BOMUploadToken, err error) {
+	return bs.uploadStream(ctx, "BOM.UploadReader", meta, "bom", bom, options...)
+}
+
+// UploadFile uploads the BOM file at path, without reading it into memory.
+// See UploadReader for details.
+func (bs BOMService) UploadFile(ctx context.Context, meta BOMUploadMetadata, path string, options ...UploadOption) (token BOMUploadToken, err error) {
+	file, err := os.Open(path) // #nosec G304 -- uploading a file chosen by the caller is the purpose of UploadFile
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
 	if err != nil {
 		return
 	}
 
-	var uploadRes bomUploadResponse
+	options = append([]UploadOption{WithContentLength(info.Size())}, options...)
+	return bs.uploadStream(ctx, "BOM.UploadFile", meta, filepath.Base(path), file, options...)
+}
+
+func (bs BOMService) uploadStream(ctx context.Context, operationName string, meta BOMUploadMetadata, fileName string, bom io.Reader, options ...UploadOption) (token BOMUploadToken, err error) {
+	if meta.hasParent() {
+		if err = bs.client.requireFeature(ctx, FeatureBOMUploadParent); err != nil {
+			return
+		}
+	}
+
+	config := uploadConfig{contentLength: -1}
+	if lr, ok := bom.(interface{ Len() int }); ok {
+		config.contentLength = int64(lr.Len())
+	}
+	for _, option := range options {
+		option(&config)
+	}
+
+	if config.onProgress != nil {
+		bom = &progressReader{
+			Reader:     bom,
+			progress:   UploadProgress{ContentLength: config.contentLength},
+			onProgress: config.onProgress,
+		}
+	}
+
+	req, err := bs.client.newRequest(ctx, http.MethodPost, "/api/v1/bom",
+		withOperationName(operationName),
+		withMultiPartStream(meta.formValues(), "bom", fileName, bom, config.compress))
+	if err != nil {
+		return
+	}
+
+	var uploadRes eventTokenResponse
 	_, err = bs.client.doRequest(req, &uploadRes)
 	if err != nil {
 		return
@@ -140,17 +558,33 @@ func (bs BOMService) PostBom(ctx context.Context, uploadReq BOMUploadRequest) (t
 	return
 }
 
-type bomProcessingResponse struct {
-	Processing bool `json:"processing"`
+// progressReader reports the number of bytes read from Reader.
+type progressReader struct {
+	io.Reader
+	progress   UploadProgress
+	onProgress func(p UploadProgress)
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.Reader.Read(p)
+	if n > 0 {
+		pr.progress.BytesRead += int64(n)
+		pr.onProgress(pr.progress)
+	}
+
+	return n, err
 }
 
+// IsBeingProcessed reports whether the BOM identified by token is still being processed.
+//
+// Deprecated: Use EventService.IsBeingProcessed with Dependency-Track v4.11.0 and newer.
 func (bs BOMService) IsBeingProcessed(ctx context.Context, token BOMUploadToken) (bool, error) {
 	req, err := bs.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/bom/token/%s", token))
 	if err != nil {
 		return false, err
 	}
 
-	var processingResponse bomProcessingResponse
+	var processingResponse eventProcessingResponse
 	_, err = bs.client.doRequest(req, &processingResponse)
 	if err != nil {
 		return false, err
@@ -158,3 +592,65 @@ func (bs BOMService) IsBeingProcessed(ctx context.Context, token BOMUploadToken)
 
 	return processingResponse.Processing, nil
 }
+
+// WaitForProcessing waits until the processing of the BOM identified by token completed.
+// A *ProcessingTimeoutError is returned when opts.Timeout is exceeded.
+//
+// Servers supporting FeatureEventTokens are polled using EventService.IsBeingProcessed. Older servers,
+// and servers whose version cannot be determined, are polled using the deprecated IsBeingProcessed.
+func (bs BOMService) WaitForProcessing(ctx context.Context, token BOMUploadToken, opts WaitOptions) (err error) {
+	ctx, endSpan := bs.client.startSpan(ctx, "BOM.WaitForProcessing")
+	defer func() { endSpan(err) }()
+
+	isBeingProcessed := func(ctx context.Context) (bool, error) {
+		return bs.IsBeingProcessed(ctx, token)
+	}
+	if supported, err := bs.client.Supports(ctx, FeatureEventTokens); err == nil && supported {
+		isBeingProcessed = func(ctx context.Context) (bool, error) {
+			return bs.client.Event.IsBeingProcessed(ctx, EventToken(token))
+		}
+	}
+
+	return waitForProcessing(ctx, string(token), opts, isBeingProcessed)
+}
+
+// UploadAndWaitOptions configures UploadAndWait.
+type UploadAndWaitOptions struct {
+	Wait           WaitOptions
+	RefreshMetrics bool // Whether to request a refresh of the project's metrics once processing completed
+}
+
+// UploadAndWait uploads a BOM using Upload, waits for its processing to complete,
+// and returns the project the BOM was uploaded to.
+//
+// Note that refreshing metrics happens asynchronously in Dependency-Track,
+// the returned project may not reflect the refreshed metrics yet.
+func (bs BOMService) UploadAndWait(ctx context.Context, uploadReq BOMUploadRequest, opts UploadAndWaitOptions) (p Project, err error) {
+	ctx, endSpan := bs.client.startSpan(ctx, "BOM.UploadAndWait")
+	defer func() { endSpan(err) }()
+
+	token, err := bs.Upload(ctx, uploadReq)
+	if err != nil {
+		return
+	}
+
+	err = bs.WaitForProcessing(ctx, token, opts.Wait)
+	if err != nil {
+		return
+	}
+
+	if uploadReq.ProjectUUID != nil {
+		p, err = bs.client.Project.Get(ctx, *uploadReq.ProjectUUID)
+	} else {
+		p, err = bs.client.Project.Lookup(ctx, uploadReq.ProjectName, uploadReq.ProjectVersion)
+	}
+	if err != nil {
+		return
+	}
+
+	if opts.RefreshMetrics {
+		err = bs.client.Metrics.RefreshProjectMetrics(ctx, p.UUID)
+	}
+
+	return
+}
@@ -29,42 +29,14 @@ func Example_uploadBOM() {
 		panic(err)
 	}
 
-	var (
-		doneChan = make(chan struct{})
-		errChan  = make(chan error)
-		ticker   = time.NewTicker(1 * time.Second)
-		timeout  = time.After(30 * time.Second)
-	)
-
-	go func() {
-		defer func() {
-			close(doneChan)
-			close(errChan)
-		}()
-
-		for {
-			select {
-			case <-ticker.C:
-				processing, err := client.BOM.IsBeingProcessed(context.TODO(), uploadToken)
-				if err != nil {
-					errChan <- err
-					return
-				}
-				if !processing {
-					doneChan <- struct{}{}
-					return
-				}
-			case <-timeout:
-				errChan <- fmt.Errorf("timeout exceeded")
-				return
-			}
-		}
-	}()
-
-	select {
-	case <-doneChan:
-		fmt.Println("bom processing completed")
-	case <-errChan:
+	err = client.BOM.WaitForProcessing(context.TODO(), uploadToken, dtrack.WaitOptions{
+		PollInterval: 1 * time.Second,
+		Timeout:      30 * time.Second,
+	})
+	if err != nil {
 		fmt.Printf("failed to wait for bom processing: %v\n", err)
+		return
 	}
+
+	fmt.Println("bom processing completed")
 }
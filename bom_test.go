@@ -0,0 +1,464 @@
+package dtrack
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/futurice/dependency-track-client-go/cyclonedx"
+)
+
+type receivedUpload struct {
+	fields        map[string]string
+	fileName      string
+	bom           string
+	contentLength int64
+	operation     string
+}
+
+func registerUploadResponder(t *testing.T, uploads *[]receivedUpload) {
+	httpmock.RegisterResponder(http.MethodPost, "http://localhost/api/v1/bom",
+		func(req *http.Request) (*http.Response, error) {
+			upload := receivedUpload{
+				fields:        make(map[string]string),
+				contentLength: req.ContentLength,
+				operation:     requestOperation(req).String(),
+			}
+
+			body := io.Reader(req.Body)
+			if req.Header.Get("Content-Encoding") == "gzip" {
+				gzipReader, err := gzip.NewReader(req.Body)
+				require.NoError(t, err)
+				body = gzipReader
+			}
+
+			_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+			require.NoError(t, err)
+
+			multipartReader := multipart.NewReader(body, params["boundary"])
+			for {
+				part, err := multipartReader.NextPart()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+
+				content, err := io.ReadAll(part)
+				require.NoError(t, err)
+
+				if part.FileName() != "" {
+					upload.fileName = part.FileName()
+					upload.bom = string(content)
+				} else {
+					upload.fields[part.FormName()] = string(content)
+				}
+			}
+
+			*uploads = append(*uploads, upload)
+			return httpmock.NewStringResponse(http.StatusOK, `{"token":"foo"}`), nil
+		})
+}
+
+func TestBOMService_UploadReader(t *testing.T) {
+	client, err := NewClient("http://localhost", WithRetryPolicy(RetryPolicy{RetryNonIdempotent: true}))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	t.Run("Streams", func(t *testing.T) {
+		httpmock.Reset()
+
+		var uploads []receivedUpload
+		registerUploadResponder(t, &uploads)
+
+		var progress []UploadProgress
+		token, err := client.BOM.UploadReader(context.TODO(), BOMUploadMetadata{
+			ProjectName:    "acme-app",
+			ProjectVersion: "1.0.0",
+			AutoCreate:     true,
+		}, strings.NewReader(`{"bomFormat":"CycloneDX"}`), WithUploadProgress(func(p UploadProgress) {
+			progress = append(progress, p)
+		}))
+		require.NoError(t, err)
+		require.Equal(t, BOMUploadToken("foo"), token)
+
+		require.Len(t, uploads, 1)
+		require.Equal(t, map[string]string{
+			"projectName":    "acme-app",
+			"projectVersion": "1.0.0",
+			"autoCreate":     "true",
+		}, uploads[0].fields)
+		require.Equal(t, "bom", uploads[0].fileName)
+		require.Equal(t, `{"bomFormat":"CycloneDX"}`, uploads[0].bom)
+		require.Equal(t, int64(-1), uploads[0].contentLength)
+		require.Equal(t, "dtrack.BOM.UploadReader", uploads[0].operation)
+
+		require.NotEmpty(t, progress)
+		last := progress[len(progress)-1]
+		require.Equal(t, int64(25), last.BytesRead)
+		require.Equal(t, int64(25), last.ContentLength)
+	})
+
+	t.Run("Gzip", func(t *testing.T) {
+		httpmock.Reset()
+
+		var uploads []receivedUpload
+		registerUploadResponder(t, &uploads)
+
+		_, err := client.BOM.UploadReader(context.TODO(), BOMUploadMetadata{ProjectName: "acme-app"},
+			io.MultiReader(strings.NewReader("<bom>"), strings.NewReader("</bom>")), WithGzip())
+		require.NoError(t, err)
+
+		require.Len(t, uploads, 1)
+		require.Equal(t, "<bom></bom>", uploads[0].bom)
+	})
+
+	t.Run("NotRetried", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder(http.MethodPost, "http://localhost/api/v1/bom",
+			httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+		_, err := client.BOM.UploadReader(context.TODO(), BOMUploadMetadata{ProjectName: "acme-app"}, bytes.NewReader([]byte("<bom/>")))
+		require.Error(t, err)
+		require.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+}
+
+func TestBOMService_UploadFile(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	var uploads []receivedUpload
+	registerUploadResponder(t, &uploads)
+
+	path := filepath.Join(t.TempDir(), "bom.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX"}`), 0o600))
+
+	var progress UploadProgress
+	_, err = client.BOM.UploadFile(context.TODO(), BOMUploadMetadata{ProjectName: "acme-app"}, path,
+		WithUploadProgress(func(p UploadProgress) {
+			progress = p
+		}))
+	require.NoError(t, err)
+
+	require.Len(t, uploads, 1)
+	require.Equal(t, "bom.json", uploads[0].fileName)
+	require.Equal(t, `{"bomFormat":"CycloneDX"}`, uploads[0].bom)
+	require.Equal(t, "dtrack.BOM.UploadFile", uploads[0].operation)
+	require.Equal(t, UploadProgress{BytesRead: 25, ContentLength: 25}, progress)
+
+	_, err = client.BOM.UploadFile(context.TODO(), BOMUploadMetadata{ProjectName: "acme-app"}, filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestBOMService_Upload_Validation(t *testing.T) {
+	client, err := NewClient("http://localhost", WithBOMValidation())
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/bom",
+		httpmock.NewStringResponder(http.StatusOK, `{"token":"foo"}`))
+	httpmock.RegisterResponder(http.MethodPost, "http://localhost/api/v1/bom",
+		httpmock.NewStringResponder(http.StatusOK, `{"token":"foo"}`))
+
+	invalidBOM := `{"bomFormat":"CycloneDX","specVersion":"1.5","components":[{"type":"library","name":"foo","purl":"foo"}]}`
+
+	_, err = client.BOM.Upload(context.TODO(), BOMUploadRequest{ProjectName: "acme-app", BOM: base64.StdEncoding.EncodeToString([]byte(invalidBOM))})
+	require.ErrorIs(t, err, ErrInvalidBOM)
+
+	var validationErr *BOMValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Len(t, validationErr.Diagnostics, 1)
+	require.Equal(t, "components[0].purl", validationErr.Diagnostics[0].Path)
+
+	_, err = client.BOM.PostBom(context.TODO(), BOMUploadRequest{ProjectName: "acme-app", BOM: invalidBOM})
+	require.ErrorIs(t, err, ErrInvalidBOM)
+	require.Zero(t, httpmock.GetTotalCallCount())
+
+	validBOM := `{"bomFormat":"CycloneDX","specVersion":"1.5","components":[{"type":"library","name":"foo","purl":"pkg:npm/foo@1.0.0"}]}`
+
+	_, err = client.BOM.PostBom(context.TODO(), BOMUploadRequest{ProjectName: "acme-app", BOM: validBOM})
+	require.NoError(t, err)
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestBOMService_ExportProject(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	projectUUID := uuid.MustParse("6fb1820f-5280-4577-ac51-40124aabe307")
+	exportURL := "http://localhost/api/v1/bom/cyclonedx/project/" + projectUUID.String()
+
+	t.Run("NegotiatesXML", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder(http.MethodGet, exportURL+"?format=XML&variant=inventory",
+			func(req *http.Request) (*http.Response, error) {
+				require.Equal(t, "application/vnd.cyclonedx+xml", req.Header.Get("Accept"))
+				return httpmock.NewStringResponse(http.StatusOK, "<bom/>"), nil
+			})
+
+		bom, err := client.BOM.ExportProject(context.TODO(), projectUUID, BOMFormatXML, BOMVariantInventory)
+		require.NoError(t, err)
+		require.Equal(t, "<bom/>", bom)
+	})
+
+	t.Run("Typed", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder(http.MethodGet, exportURL+"?format=JSON&variant=withVulnerabilities",
+			func(req *http.Request) (*http.Response, error) {
+				require.Equal(t, "application/vnd.cyclonedx+json", req.Header.Get("Accept"))
+				return httpmock.NewStringResponse(http.StatusOK, `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [{"type": "library", "bom-ref": "log4j", "name": "log4j-core", "version": "2.14.1"}],
+  "vulnerabilities": [{
+    "bom-ref": "vuln",
+    "id": "CVE-2021-44228",
+    "source": {"name": "NVD"},
+    "ratings": [{"score": 10.0, "severity": "critical", "method": "CVSSv31"}],
+    "cwes": [502],
+    "analysis": {"state": "exploitable", "response": ["update"]},
+    "affects": [{"ref": "log4j"}]
+  }]
+}`), nil
+			})
+
+		bom, err := client.BOM.ExportProjectBOM(context.TODO(), projectUUID, BOMVariantWithVulnerabilities)
+		require.NoError(t, err)
+		require.Len(t, bom.Components, 1)
+		require.Len(t, bom.Vulnerabilities, 1)
+
+		vulnerability := bom.Vulnerabilities[0]
+		require.Equal(t, "CVE-2021-44228", vulnerability.ID)
+		require.Equal(t, "NVD", vulnerability.Source.Name)
+		require.Equal(t, 10.0, *vulnerability.Ratings[0].Score)
+		require.Equal(t, []int{502}, vulnerability.CWEs)
+		require.Equal(t, "exploitable", vulnerability.Analysis.State)
+		require.Equal(t, []string{"update"}, vulnerability.Analysis.Responses)
+		require.Equal(t, "log4j", vulnerability.Affects[0].Ref)
+		require.Empty(t, bom.Validate())
+	})
+
+	t.Run("Writer", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder(http.MethodGet, exportURL+"?format=XML&variant=vdr",
+			httpmock.NewStringResponder(http.StatusOK, "<bom>\n</bom>\n"))
+
+		var buf bytes.Buffer
+		err := client.BOM.ExportProjectTo(context.TODO(), projectUUID, BOMFormatXML, BOMVariantVDR, &buf)
+		require.NoError(t, err)
+		require.Equal(t, "<bom>\n</bom>\n", buf.String())
+	})
+}
+
+func TestBOMService_UploadSPDX(t *testing.T) {
+	client, err := NewClient("http://localhost", WithBOMValidation())
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	var uploadReq BOMUploadRequest
+	httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/bom",
+		func(req *http.Request) (*http.Response, error) {
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&uploadReq))
+			return httpmock.NewStringResponse(http.StatusOK, `{"token":"foo"}`), nil
+		})
+
+	document, err := os.ReadFile("./spdx/testdata/document.spdx")
+	require.NoError(t, err)
+
+	token, report, err := client.BOM.UploadSPDX(context.TODO(), BOMUploadMetadata{ProjectName: "acme-app", ProjectVersion: "1.0.0", AutoCreate: true}, document)
+	require.NoError(t, err)
+	require.Equal(t, BOMUploadToken("foo"), token)
+	require.NotEmpty(t, report.Unmapped)
+
+	require.Equal(t, "acme-app", uploadReq.ProjectName)
+	require.Equal(t, "1.0.0", uploadReq.ProjectVersion)
+	require.True(t, uploadReq.AutoCreate)
+
+	content, err := base64.StdEncoding.DecodeString(uploadReq.BOM)
+	require.NoError(t, err)
+
+	bom, format, err := cyclonedx.Parse(content)
+	require.NoError(t, err)
+	require.Equal(t, cyclonedx.FormatJSON, format)
+	require.Equal(t, "acme-app", bom.Metadata.Component.Name)
+	require.Len(t, bom.Components, 3)
+
+	_, _, err = client.BOM.UploadSPDX(context.TODO(), BOMUploadMetadata{ProjectName: "acme-app"}, []byte("not an sbom"))
+	require.Error(t, err)
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestBOMService_UploadMerged(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	var uploads []BOMUploadRequest
+	registerPutResponder := func() {
+		httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/bom",
+			func(req *http.Request) (*http.Response, error) {
+				var uploadReq BOMUploadRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&uploadReq))
+				uploads = append(uploads, uploadReq)
+				return httpmock.NewStringResponse(http.StatusOK, fmt.Sprintf(`{"token":"token-%d"}`, len(uploads))), nil
+			})
+	}
+
+	parts := []BOMPart{
+		{Name: "backend", BOM: []byte(`{"bomFormat":"CycloneDX","specVersion":"1.5","components":[
+  {"type":"library","bom-ref":"lodash","name":"lodash","version":"4.17.21","purl":"pkg:npm/lodash@4.17.21"},
+  {"type":"library","bom-ref":"express","name":"express","version":"4.18.2","purl":"pkg:npm/express@4.18.2"}
+]}`)},
+		{Name: "frontend", Version: "2.0.0", BOM: []byte(`<?xml version="1.0"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4">
+  <components>
+    <component type="library" bom-ref="pkg:npm/lodash@4.17.21"><name>lodash</name><version>4.17.21</version><purl>pkg:npm/lodash@4.17.21</purl></component>
+  </components>
+</bom>`)},
+	}
+
+	t.Run("Flat", func(t *testing.T) {
+		httpmock.Reset()
+		uploads = nil
+		registerPutResponder()
+
+		tokens, err := client.BOM.UploadMerged(context.TODO(), MergedUploadRequest{
+			Mode:    MergeModeFlat,
+			Project: BOMUploadMetadata{ProjectName: "acme-product", ProjectVersion: "1.0.0", AutoCreate: true},
+			Parts:   parts,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []BOMUploadToken{"token-1"}, tokens)
+
+		require.Len(t, uploads, 1)
+		require.Equal(t, "acme-product", uploads[0].ProjectName)
+
+		content, err := base64.StdEncoding.DecodeString(uploads[0].BOM)
+		require.NoError(t, err)
+
+		bom, err := cyclonedx.ParseJSON(content)
+		require.NoError(t, err)
+		require.Equal(t, cyclonedx.SpecVersion1_5, bom.SpecVersion)
+		require.Len(t, bom.Components, 2)
+	})
+
+	t.Run("FlatRetainedFields", func(t *testing.T) {
+		httpmock.Reset()
+		uploads = nil
+		registerPutResponder()
+
+		_, err := client.BOM.UploadMerged(context.TODO(), MergedUploadRequest{
+			Mode:    MergeModeFlat,
+			Project: BOMUploadMetadata{ProjectName: "acme-product", ProjectVersion: "1.0.0"},
+			Parts: []BOMPart{{BOM: []byte(`{"bomFormat":"CycloneDX","specVersion":"1.5",
+  "metadata":{"timestamp":"2024-01-01T00:00:00Z","tools":[{"vendor":"acme","name":"scanner"}],"component":{"type":"application","bom-ref":"app","name":"app"}},
+  "components":[{"type":"library","bom-ref":"lodash","supplier":{"name":"OpenJS"},"name":"lodash","version":"4.17.21","purl":"pkg:npm/lodash@4.17.21",
+    "hashes":[{"alg":"SHA-1","content":"679591c564c3bffaae8454cf0b3df370c3d6911c"}],"licenses":[{"license":{"id":"MIT"}}],"properties":[{"name":"scope","value":"prod"}]}],
+  "dependencies":[{"ref":"app","dependsOn":["lodash"]}],
+  "compositions":[{"aggregate":"complete"}]
+}`)}},
+		})
+		require.NoError(t, err)
+		require.Len(t, uploads, 1)
+
+		content, err := base64.StdEncoding.DecodeString(uploads[0].BOM)
+		require.NoError(t, err)
+
+		var merged map[string]any
+		require.NoError(t, json.Unmarshal(content, &merged))
+		require.NotContains(t, merged, "metadata")
+		require.NotContains(t, merged, "compositions")
+		require.Equal(t, []any{map[string]any{"ref": "app", "dependsOn": []any{"lodash"}}}, merged["dependencies"])
+
+		components := merged["components"].([]any)
+		require.Len(t, components, 2)
+		require.Equal(t, "app", components[0].(map[string]any)["name"])
+
+		lodash := components[1].(map[string]any)
+		require.NotContains(t, lodash, "supplier")
+		for _, field := range []string{"bom-ref", "name", "version", "purl", "hashes", "licenses", "properties"} {
+			require.Contains(t, lodash, field)
+		}
+	})
+
+	t.Run("Hierarchical", func(t *testing.T) {
+		httpmock.Reset()
+		uploads = nil
+		registerPutResponder()
+
+		parentUUID := uuid.MustParse("6fb1820f-5280-4577-ac51-40124aabe307")
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/lookup?name=acme-product&version=1.0.0",
+			httpmock.NewStringResponder(http.StatusNotFound, "The project could not be found."))
+		httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/project",
+			func(req *http.Request) (*http.Response, error) {
+				var project Project
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&project))
+				require.Equal(t, "acme-product", project.Name)
+				require.True(t, project.Active)
+
+				project.UUID = parentUUID
+				return httpmock.NewJsonResponse(http.StatusCreated, project)
+			})
+
+		tokens, err := client.BOM.UploadMerged(context.TODO(), MergedUploadRequest{
+			Mode:    MergeModeHierarchical,
+			Project: BOMUploadMetadata{ProjectName: "acme-product", ProjectVersion: "1.0.0", AutoCreate: true},
+			Parts:   parts,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []BOMUploadToken{"token-1", "token-2"}, tokens)
+
+		require.Len(t, uploads, 2)
+		for i, version := range []string{"1.0.0", "2.0.0"} {
+			require.Equal(t, parts[i].Name, uploads[i].ProjectName)
+			require.Equal(t, version, uploads[i].ProjectVersion)
+			require.Equal(t, &parentUUID, uploads[i].ParentUUID)
+			require.True(t, uploads[i].AutoCreate)
+
+			content, err := base64.StdEncoding.DecodeString(uploads[i].BOM)
+			require.NoError(t, err)
+			require.Equal(t, parts[i].BOM, content)
+		}
+	})
+
+	t.Run("InvalidRequest", func(t *testing.T) {
+		httpmock.Reset()
+
+		_, err := client.BOM.UploadMerged(context.TODO(), MergedUploadRequest{Mode: MergeModeFlat})
+		require.Error(t, err)
+
+		_, err = client.BOM.UploadMerged(context.TODO(), MergedUploadRequest{Mode: MergeModeHierarchical, Parts: []BOMPart{{BOM: parts[0].BOM}}})
+		require.EqualError(t, err, "bom part 0 has no name")
+		require.Zero(t, httpmock.GetTotalCallCount())
+	})
+}
@@ -0,0 +1,324 @@
+package bomdiff
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+	"github.com/futurice/dependency-track-client-go/internal/packageurl"
+	"github.com/futurice/dependency-track-client-go/internal/semver"
+)
+
+// Diff describes the changes from one project to another.
+type Diff struct {
+	From                      Project         `json:"from"`
+	To                        Project         `json:"to"`
+	Added                     []Component     `json:"added"`
+	Removed                   []Component     `json:"removed"`
+	VersionChanges            []VersionChange `json:"versionChanges"`
+	LicenseChanges            []LicenseChange `json:"licenseChanges"`
+	IntroducedVulnerabilities []Vulnerability `json:"introducedVulnerabilities"`
+	ResolvedVulnerabilities   []Vulnerability `json:"resolvedVulnerabilities"`
+}
+
+// IsEmpty reports whether the projects have no differences.
+func (d Diff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 &&
+		len(d.VersionChanges) == 0 && len(d.LicenseChanges) == 0 &&
+		len(d.IntroducedVulnerabilities) == 0 && len(d.ResolvedVulnerabilities) == 0
+}
+
+type Project struct {
+	UUID    uuid.UUID `json:"uuid"`
+	Name    string    `json:"name"`
+	Version string    `json:"version,omitempty"`
+}
+
+func (p Project) String() string {
+	if p.Version == "" {
+		return p.Name
+	}
+
+	return p.Name + "@" + p.Version
+}
+
+type Component struct {
+	Key     string `json:"key"` // Package URL without version, or group/name
+	Group   string `json:"group,omitempty"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	PURL    string `json:"purl,omitempty"`
+	License string `json:"license,omitempty"`
+}
+
+func (c Component) String() string {
+	if c.Group == "" {
+		return c.Name
+	}
+
+	return c.Group + "/" + c.Name
+}
+
+type Direction string
+
+const (
+	DirectionUpgrade   Direction = "upgrade"
+	DirectionDowngrade Direction = "downgrade"
+	DirectionUnknown   Direction = "unknown" // At least one of the versions is not a semantic version
+)
+
+type VersionChange struct {
+	From      Component `json:"from"`
+	To        Component `json:"to"`
+	Direction Direction `json:"direction"`
+}
+
+type LicenseChange struct {
+	Component Component `json:"component"` // As found in the newer project
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+}
+
+type Vulnerability struct {
+	VulnID    string          `json:"vulnId"`
+	Source    string          `json:"source"`
+	Severity  dtrack.Severity `json:"severity"`
+	Title     string          `json:"title,omitempty"`
+	Component Component       `json:"component"`
+}
+
+// Compare fetches the components and unsuppressed findings of both projects and compares them.
+// projectA is considered the older, projectB the newer project.
+func Compare(ctx context.Context, client *dtrack.Client, projectA, projectB uuid.UUID) (d Diff, err error) {
+	a, err := fetch(ctx, client, projectA)
+	if err != nil {
+		return
+	}
+
+	b, err := fetch(ctx, client, projectB)
+	if err != nil {
+		return
+	}
+
+	d = compare(a, b)
+	return
+}
+
+// snapshot is the state of a project relevant for comparison.
+type snapshot struct {
+	project    Project
+	components []dtrack.Component
+	findings   []dtrack.Finding
+}
+
+func fetch(ctx context.Context, client *dtrack.Client, projectUUID uuid.UUID) (s snapshot, err error) {
+	project, err := client.Project.Get(ctx, projectUUID)
+	if err != nil {
+		return s, fmt.Errorf("failed to fetch project %s: %w", projectUUID, err)
+	}
+	s.project = Project{UUID: project.UUID, Name: project.Name, Version: project.Version}
+
+	for component, err := range client.Component.All(ctx, projectUUID) {
+		if err != nil {
+			return s, fmt.Errorf("failed to fetch components of project %s: %w", projectUUID, err)
+		}
+		s.components = append(s.components, component)
+	}
+
+	for finding, err := range client.Finding.All(ctx, projectUUID, false) {
+		if err != nil {
+			return s, fmt.Errorf("failed to fetch findings of project %s: %w", projectUUID, err)
+		}
+		if !finding.Analysis.Suppressed {
+			s.findings = append(s.findings, finding)
+		}
+	}
+
+	return
+}
+
+func compare(a, b snapshot) Diff {
+	d := Diff{From: a.project, To: b.project}
+
+	componentsA := groupComponents(a.components)
+	componentsB := groupComponents(b.components)
+
+	for key, as := range componentsA {
+		bs, ok := componentsB[key]
+		if !ok {
+			d.Removed = append(d.Removed, as...)
+			continue
+		}
+
+		// Components present in the same version in both projects can only differ in their license.
+		var removed, added []Component
+		for _, ca := range as {
+			i := slices.IndexFunc(bs, func(cb Component) bool { return cb.Version == ca.Version })
+			if i < 0 {
+				removed = append(removed, ca)
+				continue
+			}
+			if cb := bs[i]; cb.License != ca.License {
+				d.LicenseChanges = append(d.LicenseChanges, LicenseChange{Component: cb, From: ca.License, To: cb.License})
+			}
+		}
+		for _, cb := range bs {
+			if !slices.ContainsFunc(as, func(ca Component) bool { return ca.Version == cb.Version }) {
+				added = append(added, cb)
+			}
+		}
+
+		// A version change can only be told apart from removals and additions
+		// if there is no more than one version of the component on either side.
+		if len(removed) == 1 && len(added) == 1 {
+			d.VersionChanges = append(d.VersionChanges, VersionChange{
+				From:      removed[0],
+				To:        added[0],
+				Direction: direction(removed[0].Version, added[0].Version),
+			})
+			if removed[0].License != added[0].License {
+				d.LicenseChanges = append(d.LicenseChanges, LicenseChange{Component: added[0], From: removed[0].License, To: added[0].License})
+			}
+			continue
+		}
+
+		d.Removed = append(d.Removed, removed...)
+		d.Added = append(d.Added, added...)
+	}
+	for key, bs := range componentsB {
+		if _, ok := componentsA[key]; !ok {
+			d.Added = append(d.Added, bs...)
+		}
+	}
+
+	findingsA := indexFindings(a.findings)
+	findingsB := indexFindings(b.findings)
+
+	for key, finding := range findingsB {
+		if _, ok := findingsA[key]; !ok {
+			d.IntroducedVulnerabilities = append(d.IntroducedVulnerabilities, convertFinding(finding))
+		}
+	}
+	for key, finding := range findingsA {
+		if _, ok := findingsB[key]; !ok {
+			d.ResolvedVulnerabilities = append(d.ResolvedVulnerabilities, convertFinding(finding))
+		}
+	}
+
+	sortComponents(d.Added)
+	sortComponents(d.Removed)
+	slices.SortFunc(d.VersionChanges, func(x, y VersionChange) int { return compareComponents(x.To, y.To) })
+	slices.SortFunc(d.LicenseChanges, func(x, y LicenseChange) int { return compareComponents(x.Component, y.Component) })
+	sortVulnerabilities(d.IntroducedVulnerabilities)
+	sortVulnerabilities(d.ResolvedVulnerabilities)
+
+	return d
+}
+
+func groupComponents(components []dtrack.Component) map[string][]Component {
+	groups := make(map[string][]Component)
+	for _, c := range components {
+		component := convertComponent(c)
+		groups[component.Key] = append(groups[component.Key], component)
+	}
+
+	return groups
+}
+
+func convertComponent(c dtrack.Component) Component {
+	component := Component{
+		Key:     componentKey(c.PURL, c.Group, c.Name),
+		Group:   c.Group,
+		Name:    c.Name,
+		Version: c.Version,
+		PURL:    c.PURL,
+		License: c.License,
+	}
+	if c.ResolvedLicense != nil && c.ResolvedLicense.LicenseID != "" {
+		component.License = c.ResolvedLicense.LicenseID
+	}
+
+	return component
+}
+
+// componentKey identifies a component independently of its version.
+func componentKey(purl, group, name string) string {
+	if purl == "" {
+		if group == "" {
+			return name
+		}
+		return group + "/" + name
+	}
+
+	return packageurl.WithoutVersion(purl)
+}
+
+func direction(from, to string) Direction {
+	vFrom, err := semver.Parse(from)
+	if err != nil {
+		return DirectionUnknown
+	}
+	vTo, err := semver.Parse(to)
+	if err != nil {
+		return DirectionUnknown
+	}
+
+	switch vFrom.Compare(vTo) {
+	case -1:
+		return DirectionUpgrade
+	case 1:
+		return DirectionDowngrade
+	default:
+		return DirectionUnknown
+	}
+}
+
+// indexFindings indexes findings by vulnerability and affected component,
+// so that a vulnerability that still affects an upgraded component is not reported as changed.
+func indexFindings(findings []dtrack.Finding) map[string]dtrack.Finding {
+	index := make(map[string]dtrack.Finding, len(findings))
+	for _, f := range findings {
+		key := f.Vulnerability.Source + "|" + f.Vulnerability.VulnID + "|" + componentKey(f.Component.PURL, f.Component.Group, f.Component.Name)
+		index[key] = f
+	}
+
+	return index
+}
+
+func convertFinding(f dtrack.Finding) Vulnerability {
+	return Vulnerability{
+		VulnID:   f.Vulnerability.VulnID,
+		Source:   f.Vulnerability.Source,
+		Severity: dtrack.Severity(f.Vulnerability.Severity),
+		Title:    f.Vulnerability.Title,
+		Component: Component{
+			Key:     componentKey(f.Component.PURL, f.Component.Group, f.Component.Name),
+			Group:   f.Component.Group,
+			Name:    f.Component.Name,
+			Version: f.Component.Version,
+			PURL:    f.Component.PURL,
+		},
+	}
+}
+
+func compareComponents(x, y Component) int {
+	return cmp.Or(cmp.Compare(x.Key, y.Key), cmp.Compare(x.Version, y.Version))
+}
+
+func sortComponents(components []Component) {
+	slices.SortFunc(components, compareComponents)
+}
+
+func sortVulnerabilities(vulnerabilities []Vulnerability) {
+	slices.SortFunc(vulnerabilities, func(x, y Vulnerability) int {
+		return cmp.Or(
+			cmp.Compare(x.Severity.Rank(), y.Severity.Rank()),
+			cmp.Compare(x.VulnID, y.VulnID),
+			compareComponents(x.Component, y.Component),
+		)
+	})
+}
@@ -0,0 +1,132 @@
+package bomdiff
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+	"github.com/futurice/dependency-track-client-go/dtracktest"
+)
+
+func TestCompare(t *testing.T) {
+	server := dtracktest.NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	projectA := server.AddProject(dtrack.Project{Name: "app", Version: "1.4.0"})
+	projectB := server.AddProject(dtrack.Project{Name: "app", Version: "1.5.0"})
+
+	for _, c := range []dtrack.Component{
+		{Name: "left-pad", Version: "1.0.0", PURL: "pkg:npm/left-pad@1.0.0", License: "MIT"},
+		{Group: "org.apache.logging.log4j", Name: "log4j-core", Version: "2.14.1", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1?type=jar", License: "Apache-2.0"},
+		{Name: "lodash", Version: "4.17.21", PURL: "pkg:npm/lodash@4.17.21", License: "MIT"},
+		{Name: "openssl", Version: "3.0.8"},
+		{Name: "react", Version: "18.2.0", PURL: "pkg:npm/react@18.2.0", License: "MIT"},
+	} {
+		server.AddComponent(projectA.UUID, c)
+	}
+	for _, c := range []dtrack.Component{
+		{Name: "is-odd", Version: "3.0.1", PURL: "pkg:npm/is-odd@3.0.1", License: "MIT"},
+		{Group: "org.apache.logging.log4j", Name: "log4j-core", Version: "2.17.1", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.17.1?type=jar", License: "Apache-2.0"},
+		{Name: "lodash", Version: "4.17.21", PURL: "pkg:npm/lodash@4.17.21", ResolvedLicense: &dtrack.License{LicenseID: "BSD-3-Clause"}},
+		{Name: "openssl", Version: "3.0.8"},
+		{Name: "react", Version: "17.0.2", PURL: "pkg:npm/react@17.0.2", License: "MIT"},
+	} {
+		server.AddComponent(projectB.UUID, c)
+	}
+
+	server.AddFinding(projectA.UUID, dtrack.Finding{
+		Component:     dtrack.FindingComponent{Group: "org.apache.logging.log4j", Name: "log4j-core", Version: "2.14.1", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1?type=jar"},
+		Vulnerability: dtrack.FindingVulnerability{VulnID: "CVE-2021-44228", Source: "NVD", Severity: "CRITICAL"},
+	})
+	server.AddFinding(projectA.UUID, dtrack.Finding{
+		Component:     dtrack.FindingComponent{Name: "openssl", Version: "3.0.8"},
+		Vulnerability: dtrack.FindingVulnerability{VulnID: "CVE-2023-0464", Source: "NVD", Severity: "HIGH"},
+	})
+	server.AddFinding(projectB.UUID, dtrack.Finding{
+		Component:     dtrack.FindingComponent{Name: "openssl", Version: "3.0.8"},
+		Vulnerability: dtrack.FindingVulnerability{VulnID: "CVE-2023-0464", Source: "NVD", Severity: "HIGH"},
+	})
+	server.AddFinding(projectB.UUID, dtrack.Finding{
+		Component:     dtrack.FindingComponent{Name: "is-odd", Version: "3.0.1", PURL: "pkg:npm/is-odd@3.0.1"},
+		Vulnerability: dtrack.FindingVulnerability{VulnID: "GHSA-xxxx-yyyy-zzzz", Source: "GITHUB", Severity: "MEDIUM"},
+	})
+	server.AddFinding(projectB.UUID, dtrack.Finding{
+		Analysis:      dtrack.FindingAnalysis{State: "FALSE_POSITIVE", Suppressed: true},
+		Component:     dtrack.FindingComponent{Name: "react", Version: "17.0.2", PURL: "pkg:npm/react@17.0.2"},
+		Vulnerability: dtrack.FindingVulnerability{VulnID: "CVE-2000-0001", Source: "NVD", Severity: "LOW"},
+	})
+
+	diff, err := Compare(context.TODO(), client, projectA.UUID, projectB.UUID)
+	require.NoError(t, err)
+
+	require.Equal(t, Project{UUID: projectA.UUID, Name: "app", Version: "1.4.0"}, diff.From)
+	require.Equal(t, Project{UUID: projectB.UUID, Name: "app", Version: "1.5.0"}, diff.To)
+
+	require.Equal(t, []Component{{Key: "pkg:npm/is-odd", Name: "is-odd", Version: "3.0.1", PURL: "pkg:npm/is-odd@3.0.1", License: "MIT"}}, diff.Added)
+	require.Equal(t, []Component{{Key: "pkg:npm/left-pad", Name: "left-pad", Version: "1.0.0", PURL: "pkg:npm/left-pad@1.0.0", License: "MIT"}}, diff.Removed)
+
+	require.Len(t, diff.VersionChanges, 2)
+	require.Equal(t, "pkg:maven/org.apache.logging.log4j/log4j-core?type=jar", diff.VersionChanges[0].To.Key)
+	require.Equal(t, "2.14.1", diff.VersionChanges[0].From.Version)
+	require.Equal(t, "2.17.1", diff.VersionChanges[0].To.Version)
+	require.Equal(t, DirectionUpgrade, diff.VersionChanges[0].Direction)
+	require.Equal(t, "pkg:npm/react", diff.VersionChanges[1].To.Key)
+	require.Equal(t, DirectionDowngrade, diff.VersionChanges[1].Direction)
+
+	require.Len(t, diff.LicenseChanges, 1)
+	require.Equal(t, "lodash", diff.LicenseChanges[0].Component.Name)
+	require.Equal(t, "MIT", diff.LicenseChanges[0].From)
+	require.Equal(t, "BSD-3-Clause", diff.LicenseChanges[0].To)
+
+	require.Len(t, diff.IntroducedVulnerabilities, 1)
+	require.Equal(t, "GHSA-xxxx-yyyy-zzzz", diff.IntroducedVulnerabilities[0].VulnID)
+	require.Equal(t, "is-odd", diff.IntroducedVulnerabilities[0].Component.Name)
+	require.Len(t, diff.ResolvedVulnerabilities, 1)
+	require.Equal(t, "CVE-2021-44228", diff.ResolvedVulnerabilities[0].VulnID)
+
+	content, err := json.Marshal(diff)
+	require.NoError(t, err)
+
+	var decoded Diff
+	require.NoError(t, json.Unmarshal(content, &decoded))
+	require.Equal(t, diff, decoded)
+
+	markdown := diff.Markdown()
+	require.Contains(t, markdown, "## Dependency changes from app@1.4.0 to app@1.5.0\n")
+	require.Contains(t, markdown, "| org.apache.logging.log4j/log4j-core | 2.14.1 | 2.17.1 | upgrade |\n")
+	require.Contains(t, markdown, "| lodash | 4.17.21 | MIT | BSD-3-Clause |\n")
+	require.Contains(t, markdown, "| GITHUB GHSA-xxxx-yyyy-zzzz | MEDIUM | is-odd | 3.0.1 |\n")
+	require.NotContains(t, markdown, "CVE-2000-0001")
+}
+
+func TestComponentKey(t *testing.T) {
+	for purl, key := range map[string]string{
+		"pkg:npm/lodash@4.17.21":                 "pkg:npm/lodash",
+		"pkg:npm/%40angular/core@16.0.0":         "pkg:npm/%40angular/core",
+		"pkg:npm/@angular/core@16.0.0":           "pkg:npm/@angular/core",
+		"pkg:npm/@angular/core":                  "pkg:npm/@angular/core",
+		"pkg:npm/@angular/common":                "pkg:npm/@angular/common",
+		"pkg:maven/org.example/foo@1.0?type=jar": "pkg:maven/org.example/foo?type=jar",
+		"pkg:golang/example.com/foo@v1.0.0#sub":  "pkg:golang/example.com/foo#sub",
+		"pkg:generic/foo":                        "pkg:generic/foo",
+	} {
+		require.Equal(t, key, componentKey(purl, "", ""), purl)
+	}
+
+	require.Equal(t, "org.example/foo", componentKey("", "org.example", "foo"))
+	require.Equal(t, "foo", componentKey("", "", "foo"))
+}
+
+func TestDiff_Markdown(t *testing.T) {
+	diff := Diff{From: Project{Name: "app", Version: "1.0.0"}, To: Project{Name: "app", Version: "1.0.1"}}
+	require.Equal(t, "## Dependency changes from app@1.0.0 to app@1.0.1\n\nNo changes.\n", diff.Markdown())
+
+	diff.Added = []Component{{Name: "a|b", Version: "1.0.0"}}
+	require.Contains(t, diff.Markdown(), "| a\\|b | 1.0.0 |  |  |\n")
+}
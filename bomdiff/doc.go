@@ -0,0 +1,10 @@
+// Package bomdiff compares the components and vulnerabilities of two Dependency-Track projects,
+// typically two versions of the same project.
+//
+// Components are matched by their package URL without the version, so that an upgrade of a package
+// is reported as a version change rather than as a removal and an addition. Components without
+// a package URL are matched by group and name. Vulnerabilities are matched by their ID, source and
+// the component they affect; suppressed findings are ignored.
+//
+// A Diff can be encoded as JSON using encoding/json, or rendered as Markdown, e.g. for pull request comments.
+package bomdiff
@@ -0,0 +1,95 @@
+package bomdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markdown renders the diff as GitHub flavored Markdown, suitable for pull request comments.
+// Sections without changes are omitted.
+func (d Diff) Markdown() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "## Dependency changes from %s to %s\n\n", escapeMarkdown(d.From.String()), escapeMarkdown(d.To.String()))
+
+	if d.IsEmpty() {
+		sb.WriteString("No changes.\n")
+		return sb.String()
+	}
+
+	fmt.Fprintf(&sb, "%d added, %d removed, %d version changes, %d license changes, %d introduced and %d resolved vulnerabilities.\n",
+		len(d.Added), len(d.Removed), len(d.VersionChanges), len(d.LicenseChanges), len(d.IntroducedVulnerabilities), len(d.ResolvedVulnerabilities))
+
+	writeComponentTable(&sb, "Added components", d.Added)
+	writeComponentTable(&sb, "Removed components", d.Removed)
+
+	if len(d.VersionChanges) > 0 {
+		writeTableHeader(&sb, "Version changes", "Component", "From", "To", "Direction")
+		for _, change := range d.VersionChanges {
+			writeTableRow(&sb, change.To.String(), change.From.Version, change.To.Version, string(change.Direction))
+		}
+	}
+
+	if len(d.LicenseChanges) > 0 {
+		writeTableHeader(&sb, "License changes", "Component", "Version", "From", "To")
+		for _, change := range d.LicenseChanges {
+			writeTableRow(&sb, change.Component.String(), change.Component.Version, change.From, change.To)
+		}
+	}
+
+	writeVulnerabilityTable(&sb, "Introduced vulnerabilities", d.IntroducedVulnerabilities)
+	writeVulnerabilityTable(&sb, "Resolved vulnerabilities", d.ResolvedVulnerabilities)
+
+	return sb.String()
+}
+
+func writeComponentTable(sb *strings.Builder, title string, components []Component) {
+	if len(components) == 0 {
+		return
+	}
+
+	writeTableHeader(sb, title, "Component", "Version", "License", "Package URL")
+	for _, c := range components {
+		writeTableRow(sb, c.String(), c.Version, c.License, c.PURL)
+	}
+}
+
+func writeVulnerabilityTable(sb *strings.Builder, title string, vulnerabilities []Vulnerability) {
+	if len(vulnerabilities) == 0 {
+		return
+	}
+
+	writeTableHeader(sb, title, "Vulnerability", "Severity", "Component", "Version")
+	for _, v := range vulnerabilities {
+		writeTableRow(sb, v.Source+" "+v.VulnID, string(v.Severity), v.Component.String(), v.Component.Version)
+	}
+}
+
+func writeTableHeader(sb *strings.Builder, title string, columns ...string) {
+	fmt.Fprintf(sb, "\n### %s\n\n", title)
+	writeTableRow(sb, columns...)
+	sb.WriteString("|" + strings.Repeat(" --- |", len(columns)) + "\n")
+}
+
+func writeTableRow(sb *strings.Builder, cells ...string) {
+	sb.WriteString("|")
+	for _, cell := range cells {
+		sb.WriteString(" " + escapeMarkdown(cell) + " |")
+	}
+	sb.WriteString("\n")
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"<", "&lt;",
+	">", "&gt;",
+	"\n", " ",
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
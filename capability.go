@@ -0,0 +1,138 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/futurice/dependency-track-client-go/internal/semver"
+)
+
+// Feature is a capability of the Dependency-Track API that is not available in all server versions.
+type Feature string
+
+const (
+	FeatureBOMUploadParent   Feature = "BOM_UPLOAD_PARENT"  // Parent fields of BOMUploadRequest
+	FeatureBOMVariantVDR     Feature = "BOM_VARIANT_VDR"    // BOMVariantVDR exports
+	FeatureEventTokens       Feature = "EVENT_TOKENS"       // EventService
+	FeaturePortfolioFindings Feature = "PORTFOLIO_FINDINGS" // FindingService.GetAllPortfolio and FindingService.GetGrouped
+	FeatureProjectAnalysis   Feature = "PROJECT_ANALYSIS"   // FindingService.AnalyzeProject
+	FeatureProjectHierarchy  Feature = "PROJECT_HIERARCHY"  // ProjectService.GetChildren and ProjectService.SetParent
+	FeatureProjectLatest     Feature = "PROJECT_LATEST"     // ProjectCloneRequest.MakeCloneLatest
+)
+
+// featureMinVersions maps features to the minimum server version supporting them.
+var featureMinVersions = map[Feature]semver.Version{
+	FeatureBOMUploadParent:   semver.MustParse("4.8.0"),
+	FeatureBOMVariantVDR:     semver.MustParse("4.7.0"),
+	FeatureEventTokens:       semver.MustParse("4.11.0"),
+	FeaturePortfolioFindings: semver.MustParse("4.9.0"),
+	FeatureProjectAnalysis:   semver.MustParse("4.7.0"),
+	FeatureProjectHierarchy:  semver.MustParse("4.7.0"),
+	FeatureProjectLatest:     semver.MustParse("4.12.0"),
+}
+
+// ErrUnsupportedByServer is returned when a feature is not supported by the server version.
+// Use errors.As with *UnsupportedError to learn which version is required.
+var ErrUnsupportedByServer = errors.New("unsupported by server")
+
+// UnsupportedError is returned when a feature requires a newer server version.
+type UnsupportedError struct {
+	Feature       Feature
+	MinVersion    string
+	ServerVersion string
+}
+
+func (e UnsupportedError) Error() string {
+	return fmt.Sprintf("%s requires Dependency-Track v%s or newer, but the server runs v%s", e.Feature, e.MinVersion, e.ServerVersion)
+}
+
+func (e UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupportedByServer
+}
+
+// WithServerVersionCheck enables checking the server version before using features
+// that are not supported by all Dependency-Track versions. The version is fetched lazily
+// using AboutService.Get when such a feature is used for the first time, and cached afterwards.
+// Unsupported features cause an UnsupportedError instead of a request being sent.
+func WithServerVersionCheck() ClientOption {
+	return func(c *Client) error {
+		c.checkServerVersion = true
+		return nil
+	}
+}
+
+// Supports reports whether the server supports feature.
+// The server version is fetched lazily and cached, regardless of WithServerVersionCheck.
+func (c Client) Supports(ctx context.Context, feature Feature) (bool, error) {
+	minVersion, ok := featureMinVersions[feature]
+	if !ok {
+		return false, fmt.Errorf("unknown feature %q", feature)
+	}
+
+	serverVersion, err := c.serverVersion.get(ctx, c.About)
+	if err != nil {
+		return false, err
+	}
+
+	return supports(serverVersion, minVersion), nil
+}
+
+// requireFeature returns an UnsupportedError when the server version check is enabled,
+// and the server does not support feature.
+func (c Client) requireFeature(ctx context.Context, feature Feature) error {
+	if !c.checkServerVersion {
+		return nil
+	}
+
+	serverVersion, err := c.serverVersion.get(ctx, c.About)
+	if err != nil {
+		return err
+	}
+
+	minVersion := featureMinVersions[feature]
+	if !supports(serverVersion, minVersion) {
+		return &UnsupportedError{
+			Feature:       feature,
+			MinVersion:    minVersion.String(),
+			ServerVersion: serverVersion.String(),
+		}
+	}
+
+	return nil
+}
+
+// supports compares versions while ignoring pre-releases,
+// because e.g. 4.8.0-SNAPSHOT builds already contain all 4.8.0 features.
+func supports(serverVersion, minVersion semver.Version) bool {
+	serverVersion.PreRelease = ""
+	return serverVersion.Compare(minVersion) >= 0
+}
+
+type serverVersionCache struct {
+	mutex   sync.Mutex
+	version *semver.Version
+}
+
+func (svc *serverVersionCache) get(ctx context.Context, as AboutService) (semver.Version, error) {
+	svc.mutex.Lock()
+	defer svc.mutex.Unlock()
+
+	if svc.version != nil {
+		return *svc.version, nil
+	}
+
+	about, err := as.Get(ctx)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("failed to determine server version: %w", err)
+	}
+
+	version, err := semver.Parse(about.Version)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("failed to determine server version: %w", err)
+	}
+
+	svc.version = &version
+	return version, nil
+}
@@ -0,0 +1,66 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Supports(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+		httpmock.NewStringResponder(http.StatusOK, `{"version":"4.7.1"}`))
+
+	supported, err := client.Supports(context.TODO(), FeatureProjectAnalysis)
+	require.NoError(t, err)
+	require.True(t, supported)
+
+	supported, err = client.Supports(context.TODO(), FeatureBOMUploadParent)
+	require.NoError(t, err)
+	require.False(t, supported)
+
+	_, err = client.Supports(context.TODO(), Feature("FOO"))
+	require.Error(t, err)
+
+	// The server version must only be requested once.
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestWithServerVersionCheck(t *testing.T) {
+	client, err := NewClient("http://localhost", WithServerVersionCheck())
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+		httpmock.NewStringResponder(http.StatusOK, `{"version":"4.6.3"}`))
+
+	_, err = client.Finding.AnalyzeProject(context.TODO(), uuid.New())
+	require.ErrorIs(t, err, ErrUnsupportedByServer)
+
+	var unsupportedErr *UnsupportedError
+	require.True(t, errors.As(err, &unsupportedErr))
+	require.Equal(t, FeatureProjectAnalysis, unsupportedErr.Feature)
+	require.Equal(t, "4.7.0", unsupportedErr.MinVersion)
+	require.Equal(t, "4.6.3", unsupportedErr.ServerVersion)
+
+	_, err = client.BOM.Upload(context.TODO(), BOMUploadRequest{ProjectName: "acme-app", ParentName: "acme"})
+	require.ErrorIs(t, err, ErrUnsupportedByServer)
+
+	_, err = client.BOM.ExportProject(context.TODO(), uuid.New(), BOMFormatJSON, BOMVariantVDR)
+	require.ErrorIs(t, err, ErrUnsupportedByServer)
+
+	// Only the server version must have been requested.
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
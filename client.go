@@ -2,6 +2,7 @@ package dtrack
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
@@ -9,15 +10,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"mime/multipart"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -28,10 +31,23 @@ const (
 type contextKey string
 
 type Client struct {
-	httpClient *http.Client
-	baseURL    *url.URL
-	userAgent  string
-	debug      bool
+	httpClient  *http.Client
+	baseURL     *url.URL
+	userAgent   string
+	retryPolicy *RetryPolicy
+	throttle    *throttleTransport
+
+	logger       *slog.Logger
+	logBodyLimit int
+
+	checkServerVersion bool
+	serverVersion      *serverVersionCache
+
+	validateBOMs bool
+
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	telemetry      *telemetry
 
 	About             AboutService
 	ACLMapping        ACLMappingService
@@ -39,6 +55,7 @@ type Client struct {
 	BOM               BOMService
 	Component         ComponentService
 	Config            ConfigService
+	Event             EventService
 	Finding           FindingService
 	License           LicenseService
 	Metrics           MetricsService
@@ -73,8 +90,8 @@ func NewClient(baseURL string, options ...ClientOption) (*Client, error) {
 		httpClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
-		userAgent: DefaultUserAgent,
-		debug:     false,
+		userAgent:     DefaultUserAgent,
+		serverVersion: &serverVersionCache{},
 	}
 
 	for _, option := range options {
@@ -83,12 +100,22 @@ func NewClient(baseURL string, options ...ClientOption) (*Client, error) {
 		}
 	}
 
+	if client.throttle != nil {
+		client.throttle.install(client.httpClient)
+	}
+
+	client.telemetry, err = newTelemetry(client.tracerProvider, client.meterProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up telemetry: %w", err)
+	}
+
 	client.About = AboutService{client: &client}
 	client.ACLMapping = ACLMappingService{client: &client}
 	client.Analysis = AnalysisService{client: &client}
 	client.BOM = BOMService{client: &client}
 	client.Component = ComponentService{client: &client}
 	client.Config = ConfigService{client: &client}
+	client.Event = EventService{client: &client}
 	client.Finding = FindingService{client: &client}
 	client.License = LicenseService{client: &client}
 	client.Metrics = MetricsService{client: &client}
@@ -122,6 +149,10 @@ func (c Client) newRequest(ctx context.Context, method, path string, options ...
 		return nil, err
 	}
 
+	if _, ok := ctx.Value(contextKeyOperation).(operation); !ok {
+		ctx = withOperation(ctx, callerOperation(1))
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
 	if err != nil {
 		return nil, err
@@ -180,25 +211,23 @@ func withBody(body interface{}) requestOption {
 
 		var (
 			contentType string
-			bodyBuf     io.ReadWriter
+			bodyBuf     = new(bytes.Buffer)
 		)
 
 		switch body := body.(type) {
 		case url.Values:
-			bodyBuf = bytes.NewBufferString("")
 			if _, err := fmt.Fprint(bodyBuf, body.Encode()); err != nil {
 				return err
 			}
 			contentType = "application/x-www-form-urlencoded"
 		default:
-			bodyBuf = new(bytes.Buffer)
 			if err := json.NewEncoder(bodyBuf).Encode(body); err != nil {
 				return err
 			}
 			contentType = "application/json"
 		}
 
-		req.Body = io.NopCloser(bodyBuf)
+		setReplayableBody(req, bodyBuf.Bytes())
 		req.Header.Set("Content-Type", contentType)
 
 		return nil
@@ -221,13 +250,76 @@ func withMultiPart(body url.Values) requestOption {
 		}
 
 		_ = multipartWriter.Close()
-		req.Body = io.NopCloser(&bodyBuf)
+		setReplayableBody(req, bodyBuf.Bytes())
+		req.Header.Set("Content-Type", multipartWriter.FormDataContentType())
+
+		return nil
+	}
+}
+
+// withMultiPartStream streams a multipart body consisting of fields and a file part with the content of file.
+// The body is produced while it is being sent, and thus can't be replayed.
+// When compress is set, the body is compressed using gzip.
+func withMultiPartStream(fields url.Values, fileField, fileName string, file io.Reader, compress bool) requestOption {
+	return func(req *http.Request) error {
+		pr, pw := io.Pipe()
+
+		var w io.Writer = pw
+		var gzipWriter *gzip.Writer
+		if compress {
+			gzipWriter = gzip.NewWriter(pw)
+			w = gzipWriter
+			req.Header.Set("Content-Encoding", "gzip")
+		}
+
+		multipartWriter := multipart.NewWriter(w)
+
+		go func() {
+			err := func() error {
+				for key, valueList := range fields {
+					for _, value := range valueList {
+						if err := multipartWriter.WriteField(key, value); err != nil {
+							return err
+						}
+					}
+				}
+
+				fw, err := multipartWriter.CreateFormFile(fileField, fileName)
+				if err != nil {
+					return err
+				}
+				if _, err = io.Copy(fw, file); err != nil {
+					return err
+				}
+				if err = multipartWriter.Close(); err != nil {
+					return err
+				}
+				if gzipWriter != nil {
+					return gzipWriter.Close()
+				}
+				return nil
+			}()
+			_ = pw.CloseWithError(err)
+		}()
+
+		req.Body = pr
+		req.ContentLength = -1
+		req.GetBody = nil
 		req.Header.Set("Content-Type", multipartWriter.FormDataContentType())
 
 		return nil
 	}
 }
 
+// setReplayableBody sets the body of req such that it can be sent multiple times.
+func setReplayableBody(req *http.Request, body []byte) {
+	req.ContentLength = int64(len(body))
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+}
+
 type Page[T any] struct {
 	Items      []T // Items on this page
 	TotalCount int // Total number of items
@@ -259,6 +351,36 @@ func withPageOptions(po PageOptions) requestOption {
 	}
 }
 
+// SortOrder is the order in which items of a collection are sorted.
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+type SortOptions struct {
+	Name  string    // Name of the field to sort by, e.g. "name"
+	Order SortOrder // Order to sort in, defaults to the server's default order
+}
+
+func withSortOptions(so SortOptions) requestOption {
+	return func(req *http.Request) error {
+		query := req.URL.Query()
+
+		if so.Name != "" {
+			query.Set("sortName", so.Name)
+		}
+		if so.Order != "" {
+			query.Set("sortOrder", string(so.Order))
+		}
+
+		req.URL.RawQuery = query.Encode()
+
+		return nil
+	}
+}
+
 func withAcceptContentType(contentType string) requestOption {
 	return func(req *http.Request) error {
 		req.Header.Set("Accept", contentType)
@@ -267,22 +389,17 @@ func withAcceptContentType(contentType string) requestOption {
 }
 
 func (c Client) doRequest(req *http.Request, v interface{}) (a apiResponse, err error) {
-	if c.debug {
-		reqDump, _ := httputil.DumpRequestOut(req, true)
-		log.Printf("sending request:\n>>>>>>\n%s\n>>>>>>\n", string(reqDump))
-	}
+	req, finishOperation := c.startOperation(req)
 
-	res, err := c.httpClient.Do(req)
+	res, attempts, err := c.send(req)
+	defer func() {
+		finishOperation(res, attempts, err)
+	}()
 	if err != nil {
 		return
 	}
 	defer res.Body.Close()
 
-	if c.debug {
-		resDump, _ := httputil.DumpResponse(res, true)
-		log.Printf("received response:\n<<<<<<\n%s\n<<<<<<\n", string(resDump))
-	}
-
 	err = checkResponseForError(res)
 	if err != nil {
 		return
@@ -297,6 +414,10 @@ func (c Client) doRequest(req *http.Request, v interface{}) (a apiResponse, err
 				err = readErr
 				return
 			}
+		case io.Writer:
+			if _, err = io.Copy(vt, res.Body); err != nil {
+				return
+			}
 		default:
 			err = json.NewDecoder(res.Body).Decode(v)
 			if err != nil {
@@ -309,6 +430,19 @@ func (c Client) doRequest(req *http.Request, v interface{}) (a apiResponse, err
 	return
 }
 
+// sendOnce performs a single attempt of req.
+func (c Client) sendOnce(req *http.Request, attempt int) (*http.Response, error) {
+	start := time.Now()
+
+	res, err := c.httpClient.Do(req)
+	c.logRequest(req, attempt, start, res, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 type apiResponse struct {
 	*http.Response
 	TotalCount int
@@ -333,11 +467,19 @@ func (c Client) newAPIResponse(res *http.Response) (a apiResponse, err error) {
 type ClientOption func(*Client) error
 
 // WithDebug toggles the debug mode.
-// When enabled, HTTP requests and responses will be logged to stderr.
-// DO NOT USE IN PRODUCTION, authorization headers are not cleared!
+// When enabled, HTTP requests and responses, including their bodies, will be logged to stderr.
+//
+// Deprecated: Use WithLogger and WithBodyLogging instead.
 func WithDebug(debug bool) ClientOption {
 	return func(c *Client) error {
-		c.debug = debug
+		if !debug {
+			c.logger = nil
+			c.logBodyLimit = 0
+			return nil
+		}
+
+		c.logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		c.logBodyLimit = 64 << 10
 		return nil
 	}
 }
@@ -3,6 +3,7 @@ package dtrack
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -63,8 +64,45 @@ func (cs ComponentService) Get(ctx context.Context, componentUUID uuid.UUID) (c
 	return
 }
 
+// ComponentListOptions filters and sorts the components returned by ComponentService.GetAllFiltered.
+type ComponentListOptions struct {
+	SearchText   string // Text to search for in component names
+	OnlyOutdated bool   // Only include components for which a newer version is known
+	OnlyDirect   bool   // Only include direct dependencies of the project
+	Sort         SortOptions
+}
+
+func (lo ComponentListOptions) params() map[string]string {
+	params := make(map[string]string)
+	if lo.SearchText != "" {
+		params["searchText"] = lo.SearchText
+	}
+	if lo.OnlyOutdated {
+		params["onlyOutdated"] = "true"
+	}
+	if lo.OnlyDirect {
+		params["onlyDirect"] = "true"
+	}
+
+	return params
+}
+
+// GetAll fetches a page of components of a project.
 func (cs ComponentService) GetAll(ctx context.Context, projectUUID uuid.UUID, po PageOptions) (p Page[Component], err error) {
-	req, err := cs.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/component/project/%s", projectUUID), withPageOptions(po))
+	return cs.getAll(ctx, "Component.GetAll", projectUUID, po, ComponentListOptions{})
+}
+
+// GetAllFiltered fetches a page of components of a project, filtered and sorted on the server according to lo.
+func (cs ComponentService) GetAllFiltered(ctx context.Context, projectUUID uuid.UUID, po PageOptions, lo ComponentListOptions) (p Page[Component], err error) {
+	return cs.getAll(ctx, "Component.GetAllFiltered", projectUUID, po, lo)
+}
+
+func (cs ComponentService) getAll(ctx context.Context, operationName string, projectUUID uuid.UUID, po PageOptions, lo ComponentListOptions) (p Page[Component], err error) {
+	req, err := cs.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/component/project/%s", projectUUID),
+		withOperationName(operationName),
+		withParams(lo.params()),
+		withSortOptions(lo.Sort),
+		withPageOptions(po))
 	if err != nil {
 		return
 	}
@@ -78,6 +116,20 @@ func (cs ComponentService) GetAll(ctx context.Context, projectUUID uuid.UUID, po
 	return
 }
 
+// All returns an iterator over all items that GetAll would return across all pages.
+func (cs ComponentService) All(ctx context.Context, projectUUID uuid.UUID, options ...IterOption) iter.Seq2[Component, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Component], error) {
+		return cs.GetAll(ctx, projectUUID, po)
+	}, options...)
+}
+
+// AllFiltered returns an iterator over all items that GetAllFiltered would return across all pages.
+func (cs ComponentService) AllFiltered(ctx context.Context, projectUUID uuid.UUID, lo ComponentListOptions, options ...IterOption) iter.Seq2[Component, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Component], error) {
+		return cs.GetAllFiltered(ctx, projectUUID, po, lo)
+	}, options...)
+}
+
 func (cs ComponentService) Create(ctx context.Context, projectUUID string, component Component) (c Component, err error) {
 	req, err := cs.client.newRequest(ctx, http.MethodPut,
 		fmt.Sprintf("/api/v1/component/project/%s", projectUUID),
@@ -0,0 +1,243 @@
+package cyclonedx
+
+import (
+	"encoding/xml"
+)
+
+const (
+	BOMFormat = "CycloneDX"
+
+	SpecVersion1_2 = "1.2"
+	SpecVersion1_3 = "1.3"
+	SpecVersion1_4 = "1.4"
+	SpecVersion1_5 = "1.5"
+	SpecVersion1_6 = "1.6"
+)
+
+// SpecVersions lists the supported spec versions, from oldest to newest.
+var SpecVersions = []string{SpecVersion1_2, SpecVersion1_3, SpecVersion1_4, SpecVersion1_5, SpecVersion1_6}
+
+const xmlNamespacePrefix = "http://cyclonedx.org/schema/bom/"
+
+// BOM is a CycloneDX document. Use Marshal to encode it, so that the
+// namespace of XML documents is set according to SpecVersion.
+type BOM struct {
+	XMLName         xml.Name        `json:"-"`
+	BOMFormat       string          `json:"bomFormat" xml:"-"`
+	SpecVersion     string          `json:"specVersion" xml:"-"`
+	SerialNumber    string          `json:"serialNumber,omitempty" xml:"serialNumber,attr,omitempty"`
+	Version         int             `json:"version,omitempty" xml:"version,attr,omitempty"`
+	Metadata        *Metadata       `json:"metadata,omitempty" xml:"metadata,omitempty"`
+	Components      []Component     `json:"components,omitempty" xml:"components>component,omitempty"`
+	Services        []Service       `json:"services,omitempty" xml:"services>service,omitempty"`
+	Dependencies    []Dependency    `json:"dependencies,omitempty" xml:"dependencies>dependency,omitempty"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities,omitempty" xml:"vulnerabilities>vulnerability,omitempty"` // Since v1.4
+}
+
+type Metadata struct {
+	Timestamp  string     `json:"timestamp,omitempty" xml:"timestamp,omitempty"`
+	Component  *Component `json:"component,omitempty" xml:"component,omitempty"`
+	Properties []Property `json:"properties,omitempty" xml:"properties>property,omitempty"`
+}
+
+type ComponentType string
+
+const (
+	ComponentTypeApplication ComponentType = "application"
+	ComponentTypeContainer   ComponentType = "container"
+	ComponentTypeDevice      ComponentType = "device"
+	ComponentTypeFile        ComponentType = "file"
+	ComponentTypeFirmware    ComponentType = "firmware"
+	ComponentTypeFramework   ComponentType = "framework"
+	ComponentTypeLibrary     ComponentType = "library"
+	ComponentTypeOS          ComponentType = "operating-system"
+)
+
+type Component struct {
+	Type               ComponentType       `json:"type" xml:"type,attr"`
+	BOMRef             string              `json:"bom-ref,omitempty" xml:"bom-ref,attr,omitempty"`
+	Author             string              `json:"author,omitempty" xml:"author,omitempty"`
+	Publisher          string              `json:"publisher,omitempty" xml:"publisher,omitempty"`
+	Group              string              `json:"group,omitempty" xml:"group,omitempty"`
+	Name               string              `json:"name" xml:"name"`
+	Version            string              `json:"version,omitempty" xml:"version,omitempty"`
+	Description        string              `json:"description,omitempty" xml:"description,omitempty"`
+	Scope              string              `json:"scope,omitempty" xml:"scope,omitempty"`
+	Hashes             []Hash              `json:"hashes,omitempty" xml:"hashes>hash,omitempty"`
+	Licenses           Licenses            `json:"licenses,omitempty" xml:"licenses,omitempty"`
+	Copyright          string              `json:"copyright,omitempty" xml:"copyright,omitempty"`
+	CPE                string              `json:"cpe,omitempty" xml:"cpe,omitempty"`
+	PURL               string              `json:"purl,omitempty" xml:"purl,omitempty"`
+	ExternalReferences []ExternalReference `json:"externalReferences,omitempty" xml:"externalReferences>reference,omitempty"`
+	Properties         []Property          `json:"properties,omitempty" xml:"properties>property,omitempty"`
+	Components         []Component         `json:"components,omitempty" xml:"components>component,omitempty"`
+}
+
+type Service struct {
+	BOMRef      string    `json:"bom-ref,omitempty" xml:"bom-ref,attr,omitempty"`
+	Group       string    `json:"group,omitempty" xml:"group,omitempty"`
+	Name        string    `json:"name" xml:"name"`
+	Version     string    `json:"version,omitempty" xml:"version,omitempty"`
+	Description string    `json:"description,omitempty" xml:"description,omitempty"`
+	Endpoints   []string  `json:"endpoints,omitempty" xml:"endpoints>endpoint,omitempty"`
+	Services    []Service `json:"services,omitempty" xml:"services>service,omitempty"`
+}
+
+type Hash struct {
+	Algorithm string `json:"alg" xml:"alg,attr"`
+	Value     string `json:"content" xml:",chardata"`
+}
+
+// Licenses is a list of licenses, or license expressions.
+type Licenses []LicenseChoice
+
+type LicenseChoice struct {
+	License    *License `json:"license,omitempty"`
+	Expression string   `json:"expression,omitempty"`
+}
+
+type License struct {
+	ID   string `json:"id,omitempty" xml:"id,omitempty"`
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
+	URL  string `json:"url,omitempty" xml:"url,omitempty"`
+}
+
+// licensesXML is the XML representation of Licenses, where licenses
+// and expressions are separate child elements.
+type licensesXML struct {
+	Licenses    []License `xml:"license"`
+	Expressions []string  `xml:"expression"`
+}
+
+func (l Licenses) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	var lx licensesXML
+	for _, choice := range l {
+		if choice.License != nil {
+			lx.Licenses = append(lx.Licenses, *choice.License)
+		}
+		if choice.Expression != "" {
+			lx.Expressions = append(lx.Expressions, choice.Expression)
+		}
+	}
+
+	return e.EncodeElement(lx, start)
+}
+
+func (l *Licenses) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var lx licensesXML
+	if err := d.DecodeElement(&lx, &start); err != nil {
+		return err
+	}
+
+	for i := range lx.Licenses {
+		*l = append(*l, LicenseChoice{License: &lx.Licenses[i]})
+	}
+	for _, expression := range lx.Expressions {
+		*l = append(*l, LicenseChoice{Expression: expression})
+	}
+
+	return nil
+}
+
+type ExternalReference struct {
+	Type    string `json:"type" xml:"type,attr"`
+	URL     string `json:"url" xml:"url"`
+	Comment string `json:"comment,omitempty" xml:"comment,omitempty"`
+}
+
+type Property struct {
+	Name  string `json:"name" xml:"name,attr"`
+	Value string `json:"value,omitempty" xml:",chardata"`
+}
+
+type Dependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// dependencyXML is the XML representation of Dependency,
+// where the dependencies are nested dependency elements.
+type dependencyXML struct {
+	Ref       string          `xml:"ref,attr"`
+	DependsOn []dependencyXML `xml:"dependency,omitempty"`
+}
+
+func (d Dependency) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	dx := dependencyXML{Ref: d.Ref}
+	for _, ref := range d.DependsOn {
+		dx.DependsOn = append(dx.DependsOn, dependencyXML{Ref: ref})
+	}
+
+	return e.EncodeElement(dx, start)
+}
+
+func (d *Dependency) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var dx dependencyXML
+	if err := dec.DecodeElement(&dx, &start); err != nil {
+		return err
+	}
+
+	d.Ref = dx.Ref
+	for _, dependency := range dx.DependsOn {
+		d.DependsOn = append(d.DependsOn, dependency.Ref)
+	}
+
+	return nil
+}
+
+// Vulnerability is a vulnerability affecting components of a BOM,
+// e.g. in VDR or VEX documents.
+type Vulnerability struct {
+	BOMRef         string                   `json:"bom-ref,omitempty" xml:"bom-ref,attr,omitempty"`
+	ID             string                   `json:"id,omitempty" xml:"id,omitempty"`
+	Source         *Source                  `json:"source,omitempty" xml:"source,omitempty"`
+	References     []VulnerabilityReference `json:"references,omitempty" xml:"references>reference,omitempty"`
+	Ratings        []Rating                 `json:"ratings,omitempty" xml:"ratings>rating,omitempty"`
+	CWEs           []int                    `json:"cwes,omitempty" xml:"cwes>cwe,omitempty"`
+	Description    string                   `json:"description,omitempty" xml:"description,omitempty"`
+	Detail         string                   `json:"detail,omitempty" xml:"detail,omitempty"`
+	Recommendation string                   `json:"recommendation,omitempty" xml:"recommendation,omitempty"`
+	Advisories     []Advisory               `json:"advisories,omitempty" xml:"advisories>advisory,omitempty"`
+	Created        string                   `json:"created,omitempty" xml:"created,omitempty"`
+	Published      string                   `json:"published,omitempty" xml:"published,omitempty"`
+	Updated        string                   `json:"updated,omitempty" xml:"updated,omitempty"`
+	Analysis       *VulnerabilityAnalysis   `json:"analysis,omitempty" xml:"analysis,omitempty"`
+	Affects        []Affects                `json:"affects,omitempty" xml:"affects>target,omitempty"`
+	Properties     []Property               `json:"properties,omitempty" xml:"properties>property,omitempty"`
+}
+
+type Source struct {
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
+	URL  string `json:"url,omitempty" xml:"url,omitempty"`
+}
+
+type VulnerabilityReference struct {
+	ID     string  `json:"id" xml:"id"`
+	Source *Source `json:"source,omitempty" xml:"source,omitempty"`
+}
+
+type Rating struct {
+	Source   *Source  `json:"source,omitempty" xml:"source,omitempty"`
+	Score    *float64 `json:"score,omitempty" xml:"score,omitempty"`
+	Severity string   `json:"severity,omitempty" xml:"severity,omitempty"`
+	Method   string   `json:"method,omitempty" xml:"method,omitempty"`
+	Vector   string   `json:"vector,omitempty" xml:"vector,omitempty"`
+}
+
+type Advisory struct {
+	Title string `json:"title,omitempty" xml:"title,omitempty"`
+	URL   string `json:"url" xml:"url"`
+}
+
+// VulnerabilityAnalysis describes the impact of a vulnerability, as determined by an analysis.
+type VulnerabilityAnalysis struct {
+	State         string   `json:"state,omitempty" xml:"state,omitempty"`
+	Justification string   `json:"justification,omitempty" xml:"justification,omitempty"`
+	Responses     []string `json:"response,omitempty" xml:"responses>response,omitempty"`
+	Detail        string   `json:"detail,omitempty" xml:"detail,omitempty"`
+}
+
+// Affects refers to a component or service affected by a vulnerability.
+type Affects struct {
+	Ref string `json:"ref" xml:"ref"`
+}
@@ -0,0 +1,7 @@
+// Package cyclonedx provides a model of CycloneDX documents, as well as functionality to
+// parse and validate them before they are uploaded to Dependency-Track.
+//
+// The model only covers the parts of the CycloneDX specification that are relevant
+// for Dependency-Track. Unknown fields are ignored when parsing.
+// JSON and XML documents of spec versions 1.2 through 1.6 are supported.
+package cyclonedx
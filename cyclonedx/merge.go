@@ -0,0 +1,191 @@
+package cyclonedx
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Merge merges multiple documents into one, e.g. the documents of the individual parts of a product.
+//
+// Components, including nested components, are deduplicated by their package URL or, if they don't
+// have one, by their bom-ref. The first occurrence of a component is retained. Metadata components
+// become regular components, so that dependencies on them are retained; the rest of the metadata
+// is dropped. Services are deduplicated by bom-ref, and vulnerabilities by ID. Nested components
+// and services are flattened.
+//
+// bom-refs of different elements that collide between documents are made unique,
+// and the dependency graphs of all documents are unioned. The documents are not modified.
+func Merge(boms ...*BOM) *BOM {
+	m := merger{
+		bom:             &BOM{BOMFormat: BOMFormat, SpecVersion: SpecVersion1_2, Version: 1},
+		components:      make(map[string]int),
+		services:        make(map[string]int),
+		vulnerabilities: make(map[string]int),
+		dependencies:    make(map[string]int),
+		refs:            make(map[string]bool),
+	}
+	for _, bom := range boms {
+		m.merge(bom)
+	}
+
+	return m.bom
+}
+
+type merger struct {
+	bom             *BOM
+	components      map[string]int  // key -> index in bom.Components
+	services        map[string]int  // key -> index in bom.Services
+	vulnerabilities map[string]int  // key -> index in bom.Vulnerabilities
+	dependencies    map[string]int  // ref -> index in bom.Dependencies
+	refs            map[string]bool // bom-refs used in bom
+}
+
+// refMapping maps the bom-refs of a document to the bom-refs of the merged document.
+type refMapping map[string]string
+
+func (rm refMapping) resolve(ref string) string {
+	if mapped, ok := rm[ref]; ok {
+		return mapped
+	}
+
+	return ref
+}
+
+func (m *merger) merge(bom *BOM) {
+	if slices.Index(SpecVersions, bom.SpecVersion) > slices.Index(SpecVersions, m.bom.SpecVersion) {
+		m.bom.SpecVersion = bom.SpecVersion
+	}
+
+	refs := make(refMapping)
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		m.component(*bom.Metadata.Component, refs)
+	}
+	for _, component := range bom.Components {
+		m.component(component, refs)
+	}
+	for _, service := range bom.Services {
+		m.service(service, refs)
+	}
+	for _, vulnerability := range bom.Vulnerabilities {
+		m.vulnerability(vulnerability, refs)
+	}
+
+	for _, dependency := range bom.Dependencies {
+		ref := refs.resolve(dependency.Ref)
+		i, ok := m.dependencies[ref]
+		if !ok {
+			i = len(m.bom.Dependencies)
+			m.dependencies[ref] = i
+			m.bom.Dependencies = append(m.bom.Dependencies, Dependency{Ref: ref})
+		}
+
+		merged := &m.bom.Dependencies[i]
+		for _, dependsOn := range dependency.DependsOn {
+			if dependsOn = refs.resolve(dependsOn); !slices.Contains(merged.DependsOn, dependsOn) {
+				merged.DependsOn = append(merged.DependsOn, dependsOn)
+			}
+		}
+	}
+}
+
+func (m *merger) component(c Component, refs refMapping) {
+	children := c.Components
+	c.Components = nil
+
+	key := "ref:" + c.BOMRef
+	switch {
+	case c.PURL != "":
+		key = "purl:" + c.PURL
+	case c.BOMRef == "":
+		key = fmt.Sprintf("name:%s/%s@%s", c.Group, c.Name, c.Version)
+	}
+
+	if i, ok := m.components[key]; ok {
+		m.mapRef(&m.bom.Components[i].BOMRef, c.BOMRef, refs)
+	} else {
+		ref := c.BOMRef
+		c.BOMRef = ""
+		m.mapRef(&c.BOMRef, ref, refs)
+		m.components[key] = len(m.bom.Components)
+		m.bom.Components = append(m.bom.Components, c)
+	}
+
+	for _, child := range children {
+		m.component(child, refs)
+	}
+}
+
+func (m *merger) service(s Service, refs refMapping) {
+	children := s.Services
+	s.Services = nil
+
+	key := "ref:" + s.BOMRef
+	if s.BOMRef == "" {
+		key = fmt.Sprintf("name:%s/%s@%s", s.Group, s.Name, s.Version)
+	}
+
+	if i, ok := m.services[key]; ok {
+		m.mapRef(&m.bom.Services[i].BOMRef, s.BOMRef, refs)
+	} else {
+		ref := s.BOMRef
+		s.BOMRef = ""
+		m.mapRef(&s.BOMRef, ref, refs)
+		m.services[key] = len(m.bom.Services)
+		m.bom.Services = append(m.bom.Services, s)
+	}
+
+	for _, child := range children {
+		m.service(child, refs)
+	}
+}
+
+// vulnerability merges v, and the elements it affects into an existing vulnerability with the same ID.
+// It must be called after all components and services of a document have been merged.
+func (m *merger) vulnerability(v Vulnerability, refs refMapping) {
+	key := "id:" + v.ID
+	if v.ID == "" {
+		key = "ref:" + v.BOMRef
+	}
+
+	affects := v.Affects
+	v.Affects = nil
+
+	i, ok := m.vulnerabilities[key]
+	if ok {
+		m.mapRef(&m.bom.Vulnerabilities[i].BOMRef, v.BOMRef, refs)
+	} else {
+		ref := v.BOMRef
+		v.BOMRef = ""
+		m.mapRef(&v.BOMRef, ref, refs)
+		i = len(m.bom.Vulnerabilities)
+		m.vulnerabilities[key] = i
+		m.bom.Vulnerabilities = append(m.bom.Vulnerabilities, v)
+	}
+
+	merged := &m.bom.Vulnerabilities[i]
+	for _, a := range affects {
+		a.Ref = refs.resolve(a.Ref)
+		if !slices.Contains(merged.Affects, a) {
+			merged.Affects = append(merged.Affects, a)
+		}
+	}
+}
+
+// mapRef maps ref of a document to the bom-ref of an element of the merged document.
+// If the merged element has no bom-ref yet, ref is claimed for it, and made unique if necessary.
+func (m *merger) mapRef(merged *string, ref string, refs refMapping) {
+	if ref == "" {
+		return
+	}
+
+	if *merged == "" {
+		unique := ref
+		for n := 2; m.refs[unique]; n++ {
+			unique = fmt.Sprintf("%s-%d", ref, n)
+		}
+		m.refs[unique] = true
+		*merged = unique
+	}
+
+	refs[ref] = *merged
+}
@@ -0,0 +1,67 @@
+package cyclonedx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	backend := &BOM{
+		BOMFormat:   BOMFormat,
+		SpecVersion: SpecVersion1_5,
+		Metadata:    &Metadata{Component: &Component{Type: ComponentTypeApplication, BOMRef: "backend", Name: "backend"}},
+		Components: []Component{
+			{Type: ComponentTypeLibrary, BOMRef: "pkg:npm/lodash@4.17.21", Name: "lodash", Version: "4.17.21", PURL: "pkg:npm/lodash@4.17.21"},
+			{Type: ComponentTypeLibrary, BOMRef: "lib-a", Name: "internal-lib"},
+		},
+		Dependencies: []Dependency{{Ref: "backend", DependsOn: []string{"pkg:npm/lodash@4.17.21", "lib-a"}}},
+		Vulnerabilities: []Vulnerability{
+			{BOMRef: "vuln", ID: "CVE-2021-23337", Affects: []Affects{{Ref: "pkg:npm/lodash@4.17.21"}}},
+		},
+	}
+	frontend := &BOM{
+		BOMFormat:   BOMFormat,
+		SpecVersion: SpecVersion1_4,
+		Metadata:    &Metadata{Component: &Component{Type: ComponentTypeApplication, BOMRef: "frontend", Name: "frontend"}},
+		Components: []Component{
+			{Type: ComponentTypeLibrary, BOMRef: "lodash", Name: "lodash", Version: "4.17.21", PURL: "pkg:npm/lodash@4.17.21"},
+			{Type: ComponentTypeLibrary, BOMRef: "lib-a", Name: "other-lib", PURL: "pkg:npm/other-lib@1.0.0", Components: []Component{
+				{Type: ComponentTypeLibrary, BOMRef: "nested", Name: "nested"},
+			}},
+		},
+		Dependencies: []Dependency{
+			{Ref: "frontend", DependsOn: []string{"lodash", "lib-a"}},
+			{Ref: "lib-a", DependsOn: []string{"nested"}},
+		},
+		Vulnerabilities: []Vulnerability{
+			{BOMRef: "vuln", ID: "CVE-2021-23337", Affects: []Affects{{Ref: "lodash"}, {Ref: "lib-a"}}},
+		},
+	}
+
+	merged := Merge(backend, frontend)
+	require.Equal(t, SpecVersion1_5, merged.SpecVersion)
+	require.Nil(t, merged.Metadata)
+
+	var refs []string
+	for _, component := range merged.Components {
+		refs = append(refs, component.BOMRef+"="+component.Name)
+		require.Empty(t, component.Components)
+	}
+	require.Equal(t, []string{"backend=backend", "pkg:npm/lodash@4.17.21=lodash", "lib-a=internal-lib", "frontend=frontend", "lib-a-2=other-lib", "nested=nested"}, refs)
+
+	require.Equal(t, []Dependency{
+		{Ref: "backend", DependsOn: []string{"pkg:npm/lodash@4.17.21", "lib-a"}},
+		{Ref: "frontend", DependsOn: []string{"pkg:npm/lodash@4.17.21", "lib-a-2"}},
+		{Ref: "lib-a-2", DependsOn: []string{"nested"}},
+	}, merged.Dependencies)
+
+	require.Len(t, merged.Vulnerabilities, 1)
+	require.Equal(t, []Affects{{Ref: "pkg:npm/lodash@4.17.21"}, {Ref: "lib-a-2"}}, merged.Vulnerabilities[0].Affects)
+
+	require.Empty(t, merged.Validate())
+
+	// Inputs are left untouched.
+	require.Len(t, frontend.Components[1].Components, 1)
+	require.Equal(t, []Affects{{Ref: "lodash"}, {Ref: "lib-a"}}, frontend.Vulnerabilities[0].Affects)
+}
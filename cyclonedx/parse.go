@@ -0,0 +1,106 @@
+package cyclonedx
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatJSON Format = "JSON"
+	FormatXML  Format = "XML"
+)
+
+// DetectFormat determines the format of a document by its first non-whitespace character.
+func DetectFormat(data []byte) (Format, error) {
+	data = bytes.TrimLeft(data, "\ufeff \t\r\n")
+	if len(data) == 0 {
+		return "", fmt.Errorf("document is empty")
+	}
+
+	switch data[0] {
+	case '{':
+		return FormatJSON, nil
+	case '<':
+		return FormatXML, nil
+	default:
+		return "", fmt.Errorf("document is neither JSON nor XML")
+	}
+}
+
+// Parse parses a JSON or XML document.
+func Parse(data []byte) (*BOM, Format, error) {
+	format, err := DetectFormat(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var bom *BOM
+	if format == FormatJSON {
+		bom, err = ParseJSON(data)
+	} else {
+		bom, err = ParseXML(data)
+	}
+
+	return bom, format, err
+}
+
+// ParseJSON parses a JSON document.
+func ParseJSON(data []byte) (*BOM, error) {
+	var bom BOM
+	if err := json.Unmarshal(data, &bom); err != nil {
+		return nil, err
+	}
+
+	return &bom, nil
+}
+
+// ParseXML parses an XML document. The spec version is determined from the namespace of the document.
+func ParseXML(data []byte) (*BOM, error) {
+	var bom BOM
+	if err := xml.Unmarshal(data, &bom); err != nil {
+		return nil, err
+	}
+	if bom.XMLName.Local != "bom" {
+		return nil, fmt.Errorf("unexpected root element %q", bom.XMLName.Local)
+	}
+
+	if strings.HasPrefix(bom.XMLName.Space, xmlNamespacePrefix) {
+		bom.BOMFormat = BOMFormat
+		bom.SpecVersion = strings.TrimPrefix(bom.XMLName.Space, xmlNamespacePrefix)
+	}
+
+	return &bom, nil
+}
+
+// Marshal encodes bom in the given format.
+// When not set, BOMFormat and SpecVersion default to CycloneDX and the newest spec version respectively.
+func Marshal(bom *BOM, format Format) ([]byte, error) {
+	b := *bom
+	if b.BOMFormat == "" {
+		b.BOMFormat = BOMFormat
+	}
+	if b.SpecVersion == "" {
+		b.SpecVersion = SpecVersions[len(SpecVersions)-1]
+	}
+
+	switch format {
+	case FormatJSON:
+		return json.Marshal(b)
+	case FormatXML:
+		b.XMLName = xml.Name{Space: xmlNamespacePrefix + b.SpecVersion, Local: "bom"}
+
+		content, err := xml.Marshal(b)
+		if err != nil {
+			return nil, err
+		}
+
+		return append([]byte(xml.Header), content...), nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
@@ -0,0 +1,88 @@
+package cyclonedx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		bom, format, err := Parse(readFile(t, "./testdata/valid.json"))
+		require.NoError(t, err)
+		require.Equal(t, FormatJSON, format)
+		require.Equal(t, SpecVersion1_5, bom.SpecVersion)
+		require.Equal(t, "acme-app", bom.Metadata.Component.Name)
+		require.Len(t, bom.Components, 2)
+		require.Equal(t, "MIT", bom.Components[0].Licenses[0].License.ID)
+		require.Equal(t, "Apache-2.0", bom.Components[1].Licenses[0].Expression)
+		require.Len(t, bom.Dependencies[0].DependsOn, 2)
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		bom, format, err := Parse(readFile(t, "./testdata/valid.xml"))
+		require.NoError(t, err)
+		require.Equal(t, FormatXML, format)
+		require.Equal(t, BOMFormat, bom.BOMFormat)
+		require.Equal(t, SpecVersion1_4, bom.SpecVersion)
+
+		require.Len(t, bom.Components, 1)
+		component := bom.Components[0]
+		require.Equal(t, ComponentTypeLibrary, component.Type)
+		require.Equal(t, "pkg:golang/github.com/google/uuid@v1.6.0", component.PURL)
+		require.Equal(t, Hash{Algorithm: "SHA-256", Value: "d2a84f4b8b650937ec8f73cd8be2c74add5a911ba64df27458ed8229da804a26"}, component.Hashes[0])
+		require.Equal(t, "BSD-3-Clause", component.Licenses[0].License.ID)
+		require.Equal(t, "https://github.com/google/uuid", component.ExternalReferences[0].URL)
+
+		require.Equal(t, []Dependency{{Ref: "acme-app", DependsOn: []string{component.BOMRef}}}, bom.Dependencies)
+	})
+
+	t.Run("UnknownFormat", func(t *testing.T) {
+		_, _, err := Parse([]byte("SPDXVersion: SPDX-2.3"))
+		require.Error(t, err)
+	})
+}
+
+func TestMarshal(t *testing.T) {
+	original, _, err := Parse(readFile(t, "./testdata/valid.xml"))
+	require.NoError(t, err)
+
+	for _, format := range []Format{FormatJSON, FormatXML} {
+		content, err := Marshal(original, format)
+		require.NoError(t, err)
+
+		parsed, parsedFormat, err := Parse(content)
+		require.NoError(t, err)
+		require.Equal(t, format, parsedFormat)
+
+		parsed.XMLName = original.XMLName
+		require.Equal(t, original, parsed)
+		require.Empty(t, Validate(content))
+	}
+}
+
+func TestMarshal_Vulnerabilities(t *testing.T) {
+	score := 9.8
+	original := &BOM{
+		BOMFormat:   BOMFormat,
+		SpecVersion: SpecVersion1_5,
+		Components:  []Component{{Type: ComponentTypeLibrary, BOMRef: "foo", Name: "foo"}},
+		Vulnerabilities: []Vulnerability{{
+			ID:       "CVE-2023-0001",
+			Source:   &Source{Name: "NVD"},
+			Ratings:  []Rating{{Score: &score, Severity: "critical", Method: "CVSSv31"}},
+			CWEs:     []int{79, 89},
+			Analysis: &VulnerabilityAnalysis{State: "not_affected", Justification: "code_not_reachable", Responses: []string{"will_not_fix"}},
+			Affects:  []Affects{{Ref: "foo"}},
+		}},
+	}
+
+	content, err := Marshal(original, FormatXML)
+	require.NoError(t, err)
+
+	parsed, err := ParseXML(content)
+	require.NoError(t, err)
+
+	parsed.XMLName = original.XMLName
+	require.Equal(t, original, parsed)
+}
@@ -0,0 +1,222 @@
+package cyclonedx
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Position is a location in a document. Line and column are 1-based.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// positionIndex maps paths of a document, as used in Diagnostic, to their positions.
+type positionIndex struct {
+	offsets    map[string]int64
+	lineStarts []int64
+}
+
+func newPositionIndex(data []byte) *positionIndex {
+	idx := &positionIndex{
+		offsets:    make(map[string]int64),
+		lineStarts: []int64{0},
+	}
+	for i, b := range data {
+		if b == '\n' {
+			idx.lineStarts = append(idx.lineStarts, int64(i+1))
+		}
+	}
+
+	return idx
+}
+
+// position resolves the position of path. When path is unknown,
+// the position of the closest known ancestor is returned.
+func (idx *positionIndex) position(path string) Position {
+	for {
+		if offset, ok := idx.offsets[path]; ok {
+			return idx.offsetPosition(offset)
+		}
+		if path == "" {
+			return Position{}
+		}
+		path = parentPath(path)
+	}
+}
+
+func (idx *positionIndex) offsetPosition(offset int64) Position {
+	line := sort.Search(len(idx.lineStarts), func(i int) bool {
+		return idx.lineStarts[i] > offset
+	})
+
+	return Position{
+		Line:   line,
+		Column: int(offset-idx.lineStarts[line-1]) + 1,
+	}
+}
+
+func parentPath(path string) string {
+	if i := strings.LastIndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+
+	return ""
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// skipSeparators returns the offset of the first character at or after offset
+// that is neither whitespace, nor a JSON separator.
+func skipSeparators(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) && bytes.IndexByte([]byte(" \t\r\n,:"), data[offset]) >= 0 {
+		offset++
+	}
+
+	return offset
+}
+
+// indexJSON records the positions of all values in a JSON document.
+func indexJSON(data []byte) *positionIndex {
+	idx := newPositionIndex(data)
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	var walk func(path string) error
+	walk = func(path string) error {
+		start := skipSeparators(data, dec.InputOffset())
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		idx.offsets[path] = start
+
+		switch token {
+		case json.Delim('{'):
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				if err = walk(joinPath(path, key.(string))); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if err = walk(indexPath(path, i)); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		}
+
+		return err
+	}
+
+	// Errors are reported by the parser, a partial index is good enough.
+	_ = walk("")
+
+	return idx
+}
+
+// xmlContainers maps XML elements that wrap lists to the name of their items.
+// Items are indexed like JSON arrays, e.g. components[0] for components>component.
+var xmlContainers = map[string]string{
+	"components":         "component",
+	"services":           "service",
+	"dependencies":       "dependency",
+	"hashes":             "hash",
+	"licenses":           "license",
+	"externalReferences": "reference",
+	"properties":         "property",
+	"endpoints":          "endpoint",
+	"vulnerabilities":    "vulnerability",
+	"references":         "reference",
+	"ratings":            "rating",
+	"cwes":               "cwe",
+	"advisories":         "advisory",
+	"responses":          "response",
+	"affects":            "target",
+}
+
+// indexXML records the positions of all elements and attributes in an XML document.
+// Paths are equivalent to those of the JSON representation of the document.
+func indexXML(data []byte) *positionIndex {
+	idx := newPositionIndex(data)
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	type frame struct {
+		name     string
+		path     string
+		children map[string]int
+	}
+	var stack []*frame
+
+	for {
+		start := skipSeparators(data, dec.InputOffset())
+		token, err := dec.RawToken()
+		if err != nil {
+			// Errors are reported by the parser, a partial index is good enough.
+			break
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			name := token.Name.Local
+
+			var (
+				path   string
+				nested bool
+			)
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				i := parent.children[name]
+				parent.children[name]++
+
+				switch {
+				case xmlContainers[parent.name] == name:
+					path = indexPath(parent.path, i)
+				case parent.name == "dependency" && name == "dependency":
+					path = indexPath(joinPath(parent.path, "dependsOn"), i)
+					nested = true
+				default:
+					path = joinPath(parent.path, name)
+				}
+			}
+
+			idx.offsets[path] = start
+			for _, attr := range token.Attr {
+				if nested && attr.Name.Local == "ref" {
+					continue // Nested dependencies are plain refs in JSON
+				}
+				idx.offsets[joinPath(path, attr.Name.Local)] = start
+			}
+			if name == "hash" {
+				idx.offsets[joinPath(path, "content")] = start
+			}
+
+			stack = append(stack, &frame{name: name, path: path, children: make(map[string]int)})
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	return idx
+}
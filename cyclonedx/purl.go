@@ -0,0 +1,76 @@
+package cyclonedx
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/futurice/dependency-track-client-go/internal/packageurl"
+)
+
+var (
+	purlTypeRegex         = regexp.MustCompile(`^[a-zA-Z.+-][a-zA-Z0-9.+-]*$`)
+	purlQualifierKeyRegex = regexp.MustCompile(`^[a-zA-Z.\-_][a-zA-Z0-9.\-_]*$`)
+)
+
+// validatePURL checks the syntax of a package URL, as defined in https://github.com/package-url/purl-spec.
+func validatePURL(purl string) error {
+	scheme, remainder, ok := strings.Cut(purl, ":")
+	if !ok || !strings.EqualFold(scheme, "pkg") {
+		return fmt.Errorf("scheme must be pkg")
+	}
+
+	remainder, subpath, _ := strings.Cut(remainder, "#")
+	remainder, qualifiers, hasQualifiers := strings.Cut(remainder, "?")
+	remainder = strings.TrimLeft(remainder, "/")
+
+	pkgType, remainder, ok := strings.Cut(remainder, "/")
+	if !ok || pkgType == "" {
+		return fmt.Errorf("type is missing")
+	}
+	if !purlTypeRegex.MatchString(pkgType) {
+		return fmt.Errorf("type %q contains invalid characters", pkgType)
+	}
+
+	if unversioned, version, ok := packageurl.CutVersion(remainder); ok {
+		if version == "" {
+			return fmt.Errorf("version is empty")
+		}
+		if _, err := url.PathUnescape(version); err != nil {
+			return fmt.Errorf("version is not properly encoded")
+		}
+		remainder = unversioned
+	}
+
+	segments := strings.Split(strings.TrimRight(remainder, "/"), "/")
+	if segments[len(segments)-1] == "" {
+		return fmt.Errorf("name is missing")
+	}
+	for _, segment := range segments {
+		if _, err := url.PathUnescape(segment); err != nil {
+			return fmt.Errorf("namespace or name is not properly encoded")
+		}
+	}
+
+	if hasQualifiers {
+		for _, qualifier := range strings.Split(qualifiers, "&") {
+			key, value, ok := strings.Cut(qualifier, "=")
+			if !ok || value == "" {
+				return fmt.Errorf("qualifier %q has no value", key)
+			}
+			if !purlQualifierKeyRegex.MatchString(key) {
+				return fmt.Errorf("qualifier key %q contains invalid characters", key)
+			}
+			if _, err := url.QueryUnescape(value); err != nil {
+				return fmt.Errorf("value of qualifier %q is not properly encoded", key)
+			}
+		}
+	}
+
+	if _, err := url.PathUnescape(subpath); err != nil {
+		return fmt.Errorf("subpath is not properly encoded")
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package cyclonedx
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "ERROR"
+	SeverityWarning Severity = "WARNING"
+)
+
+// Diagnostic describes a problem found in a document.
+type Diagnostic struct {
+	Severity Severity
+	Path     string   // Path of the offending value in JSON notation, e.g. components[0].purl
+	Position Position // Position of the offending value, if known
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	var sb strings.Builder
+	if d.Position.Line > 0 {
+		fmt.Fprintf(&sb, "%d:%d: ", d.Position.Line, d.Position.Column)
+	}
+	if d.Path != "" {
+		sb.WriteString(d.Path + ": ")
+	}
+	sb.WriteString(strings.ToLower(string(d.Severity)) + ": " + d.Message)
+
+	return sb.String()
+}
+
+// HasErrors reports whether any of diagnostics has SeverityError.
+func HasErrors(diagnostics []Diagnostic) bool {
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hashLengths maps hash algorithms to the length of their hex-encoded values.
+// BLAKE3 has a variable output length.
+var hashLengths = map[string][]int{
+	"MD5":         {32},
+	"SHA-1":       {40},
+	"SHA-256":     {64},
+	"SHA-384":     {96},
+	"SHA-512":     {128},
+	"SHA3-256":    {64},
+	"SHA3-384":    {96},
+	"SHA3-512":    {128},
+	"BLAKE2b-256": {64},
+	"BLAKE2b-384": {96},
+	"BLAKE2b-512": {128},
+	"BLAKE3":      {32, 40, 64, 96, 128},
+}
+
+var hexRegex = regexp.MustCompile(`^[a-fA-F0-9]+$`)
+
+// Validate parses a JSON or XML document and validates it.
+// Syntax errors are reported as a single diagnostic.
+func Validate(data []byte) []Diagnostic {
+	format, err := DetectFormat(data)
+	if err != nil {
+		return []Diagnostic{{Severity: SeverityError, Message: err.Error()}}
+	}
+
+	var (
+		bom *BOM
+		idx *positionIndex
+	)
+	if format == FormatJSON {
+		bom, err = ParseJSON(data)
+		idx = indexJSON(data)
+	} else {
+		bom, err = ParseXML(data)
+		idx = indexXML(data)
+	}
+	if err != nil {
+		return []Diagnostic{syntaxDiagnostic(data, err)}
+	}
+
+	diagnostics := bom.Validate()
+	for i := range diagnostics {
+		diagnostics[i].Position = idx.position(diagnostics[i].Path)
+	}
+
+	return diagnostics
+}
+
+func syntaxDiagnostic(data []byte, err error) Diagnostic {
+	d := Diagnostic{Severity: SeverityError, Message: err.Error()}
+
+	var (
+		jsonSyntaxErr *json.SyntaxError
+		jsonTypeErr   *json.UnmarshalTypeError
+		xmlSyntaxErr  *xml.SyntaxError
+	)
+	switch {
+	case errors.As(err, &jsonSyntaxErr):
+		d.Position = newPositionIndex(data).offsetPosition(jsonSyntaxErr.Offset)
+	case errors.As(err, &jsonTypeErr):
+		d.Path = jsonTypeErr.Field
+		d.Position = newPositionIndex(data).offsetPosition(jsonTypeErr.Offset)
+	case errors.As(err, &xmlSyntaxErr):
+		d.Position = Position{Line: xmlSyntaxErr.Line}
+	}
+
+	return d
+}
+
+// Validate checks the document for problems that would cause Dependency-Track
+// to reject it, or to process it incompletely. Diagnostics do not include positions,
+// use the package-level Validate function for that.
+func (b *BOM) Validate() []Diagnostic {
+	v := validator{refs: make(map[string]string)}
+
+	if b.BOMFormat != BOMFormat {
+		v.errorf("bomFormat", "bomFormat must be %q, but is %q", BOMFormat, b.BOMFormat)
+	}
+	if !slices.Contains(SpecVersions, b.SpecVersion) {
+		v.errorf("specVersion", "spec version %q is not supported, must be one of %s", b.SpecVersion, strings.Join(SpecVersions, ", "))
+	}
+
+	if b.Metadata != nil && b.Metadata.Component != nil {
+		v.component("metadata.component", *b.Metadata.Component)
+	}
+	for i, component := range b.Components {
+		v.component(indexPath("components", i), component)
+	}
+	for i, service := range b.Services {
+		v.service(indexPath("services", i), service)
+	}
+
+	for i, vulnerability := range b.Vulnerabilities {
+		v.declareRef(indexPath("vulnerabilities", i), vulnerability.BOMRef)
+	}
+
+	seenDependencies := make(map[string]string)
+	for i, dependency := range b.Dependencies {
+		path := indexPath("dependencies", i)
+		v.ref(joinPath(path, "ref"), dependency.Ref)
+		if previous, ok := seenDependencies[dependency.Ref]; ok && dependency.Ref != "" {
+			v.warnf(joinPath(path, "ref"), "dependencies of %q are already declared at %s", dependency.Ref, previous)
+		} else {
+			seenDependencies[dependency.Ref] = path
+		}
+
+		for j, ref := range dependency.DependsOn {
+			v.ref(indexPath(joinPath(path, "dependsOn"), j), ref)
+		}
+	}
+
+	for i, vulnerability := range b.Vulnerabilities {
+		path := joinPath(indexPath("vulnerabilities", i), "affects")
+		for j, affects := range vulnerability.Affects {
+			v.ref(joinPath(indexPath(path, j), "ref"), affects.Ref)
+		}
+	}
+
+	return v.diagnostics
+}
+
+type validator struct {
+	refs        map[string]string // bom-ref -> path of its declaration
+	diagnostics []Diagnostic
+}
+
+func (v *validator) errorf(path, format string, args ...interface{}) {
+	v.diagnostics = append(v.diagnostics, Diagnostic{Severity: SeverityError, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) warnf(path, format string, args ...interface{}) {
+	v.diagnostics = append(v.diagnostics, Diagnostic{Severity: SeverityWarning, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) declareRef(path, ref string) {
+	if ref == "" {
+		return
+	}
+
+	path = joinPath(path, "bom-ref")
+	if previous, ok := v.refs[ref]; ok {
+		v.errorf(path, "bom-ref %q is already declared at %s", ref, previous)
+		return
+	}
+
+	v.refs[ref] = path
+}
+
+func (v *validator) component(path string, c Component) {
+	v.declareRef(path, c.BOMRef)
+
+	if c.Name == "" {
+		v.errorf(joinPath(path, "name"), "name is required")
+	}
+	if c.PURL != "" {
+		if err := validatePURL(c.PURL); err != nil {
+			v.errorf(joinPath(path, "purl"), "invalid purl %q: %v", c.PURL, err)
+		}
+	}
+
+	for i, hash := range c.Hashes {
+		v.hash(indexPath(joinPath(path, "hashes"), i), hash)
+	}
+	for i, child := range c.Components {
+		v.component(indexPath(joinPath(path, "components"), i), child)
+	}
+}
+
+func (v *validator) service(path string, s Service) {
+	v.declareRef(path, s.BOMRef)
+
+	if s.Name == "" {
+		v.errorf(joinPath(path, "name"), "name is required")
+	}
+	for i, child := range s.Services {
+		v.service(indexPath(joinPath(path, "services"), i), child)
+	}
+}
+
+func (v *validator) hash(path string, h Hash) {
+	lengths, ok := hashLengths[h.Algorithm]
+	if !ok {
+		v.errorf(joinPath(path, "alg"), "unknown hash algorithm %q", h.Algorithm)
+		return
+	}
+
+	content := strings.TrimSpace(h.Value)
+	if !hexRegex.MatchString(content) {
+		v.errorf(joinPath(path, "content"), "%s hash must be hex-encoded", h.Algorithm)
+		return
+	}
+	if !slices.Contains(lengths, len(content)) {
+		v.errorf(joinPath(path, "content"), "%s hash has invalid length %d", h.Algorithm, len(content))
+	}
+}
+
+// ref checks that ref refers to a declared bom-ref.
+// It must only be called after all bom-refs have been declared.
+func (v *validator) ref(path, ref string) {
+	if ref == "" {
+		v.errorf(path, "ref is empty")
+		return
+	}
+	if _, ok := v.refs[ref]; !ok {
+		v.errorf(path, "ref %q does not refer to any component, service or vulnerability", ref)
+	}
+}
@@ -0,0 +1,128 @@
+package cyclonedx
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("ValidJSON", func(t *testing.T) {
+		require.Empty(t, Validate(readFile(t, "./testdata/valid.json")))
+	})
+
+	t.Run("ValidXML", func(t *testing.T) {
+		require.Empty(t, Validate(readFile(t, "./testdata/valid.xml")))
+	})
+
+	t.Run("InvalidJSON", func(t *testing.T) {
+		diagnostics := Validate(readFile(t, "./testdata/invalid.json"))
+		require.True(t, HasErrors(diagnostics))
+		require.Equal(t, []Diagnostic{
+			{
+				Severity: SeverityError,
+				Path:     "components[0].purl",
+				Position: Position{Line: 11, Column: 15},
+				Message:  `invalid purl "npm/lodash@4.17.20": scheme must be pkg`,
+			},
+			{
+				Severity: SeverityError,
+				Path:     "components[0].hashes[0].content",
+				Position: Position{Line: 15, Column: 22},
+				Message:  "SHA-1 hash has invalid length 3",
+			},
+			{
+				Severity: SeverityError,
+				Path:     "components[1].bom-ref",
+				Position: Position{Line: 21, Column: 18},
+				Message:  `bom-ref "lodash" is already declared at components[0].bom-ref`,
+			},
+			{
+				Severity: SeverityError,
+				Path:     "dependencies[0].dependsOn[0]",
+				Position: Position{Line: 30, Column: 9},
+				Message:  `ref "underscore" does not refer to any component, service or vulnerability`,
+			},
+		}, diagnostics)
+	})
+
+	t.Run("InvalidXML", func(t *testing.T) {
+		diagnostics := Validate(readFile(t, "./testdata/invalid.xml"))
+		require.Equal(t, []Diagnostic{
+			{
+				Severity: SeverityError,
+				Path:     "dependencies[0].dependsOn[0]",
+				Position: Position{Line: 11, Column: 7},
+				Message:  `ref "cmp" does not refer to any component, service or vulnerability`,
+			},
+		}, diagnostics)
+	})
+
+	t.Run("SyntaxError", func(t *testing.T) {
+		diagnostics := Validate([]byte("{\n  \"bomFormat\": \"CycloneDX\",\n  \"specVersion\": 1.5\n  \"version\": 1\n}"))
+		require.Len(t, diagnostics, 1)
+		require.Equal(t, SeverityError, diagnostics[0].Severity)
+		require.Equal(t, 4, diagnostics[0].Position.Line)
+	})
+
+	t.Run("UnsupportedSpecVersion", func(t *testing.T) {
+		diagnostics := Validate([]byte(`<bom xmlns="http://cyclonedx.org/schema/bom/1.1" version="1"/>`))
+		require.Len(t, diagnostics, 1)
+		require.Equal(t, "specVersion", diagnostics[0].Path)
+		require.Equal(t, Position{Line: 1, Column: 1}, diagnostics[0].Position)
+	})
+
+	t.Run("DuplicateDependencies", func(t *testing.T) {
+		bom := BOM{
+			BOMFormat:   BOMFormat,
+			SpecVersion: SpecVersion1_6,
+			Components:  []Component{{Name: "foo", BOMRef: "foo"}},
+			Dependencies: []Dependency{
+				{Ref: "foo"},
+				{Ref: "foo"},
+			},
+		}
+
+		diagnostics := bom.Validate()
+		require.False(t, HasErrors(diagnostics))
+		require.Len(t, diagnostics, 1)
+		require.Equal(t, SeverityWarning, diagnostics[0].Severity)
+		require.Equal(t, "dependencies[1].ref", diagnostics[0].Path)
+	})
+}
+
+func TestValidatePURL(t *testing.T) {
+	for _, purl := range []string{
+		"pkg:npm/lodash@4.17.21",
+		"pkg:npm/%40angular/core@16.2.0",
+		"pkg:npm/@angular/core",
+		"pkg:maven/org.apache.logging.log4j/log4j-core@2.17.1?type=jar&classifier=sources",
+		"pkg:golang/github.com/google/uuid@v1.6.0#internal",
+		"pkg:deb/debian/curl@7.50.3-1?arch=i386&distro=jessie",
+	} {
+		require.NoError(t, validatePURL(purl), purl)
+	}
+
+	for purl, message := range map[string]string{
+		"npm/lodash@4.17.21":         "scheme must be pkg",
+		"pkg:lodash":                 "type is missing",
+		"pkg:1npm/lodash":            `type "1npm" contains invalid characters`,
+		"pkg:npm/":                   "name is missing",
+		"pkg:npm/lodash@":            "version is empty",
+		"pkg:npm/lodash?type":        `qualifier "type" has no value`,
+		"pkg:npm/lodash?t%20ype=jar": `qualifier key "t%20ype" contains invalid characters`,
+		"pkg:npm/lod%zzash@4.17.21":  "namespace or name is not properly encoded",
+	} {
+		err := validatePURL(purl)
+		require.Error(t, err, purl)
+		require.Equal(t, message, err.Error(), purl)
+	}
+}
+
+func readFile(t *testing.T, path string) []byte {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	return content
+}
@@ -0,0 +1,14 @@
+// Package dtracktest provides an in-memory fake of the Dependency-Track API for use in tests.
+//
+// The fake keeps projects, project properties, components, findings, analyses, policies, policy violations,
+// teams, ACL mappings and event tokens in memory, and implements the endpoints the dtrack package uses to manage them.
+// Collections are paginated the same way Dependency-Track paginates them, including the X-Total-Count header.
+//
+// Requests to other endpoints are answered with 404 Not Found. Query parameters that filter or sort
+// a collection, but that the fake does not implement, are answered with 501 Not Implemented,
+// so that tests don't silently receive unfiltered results.
+//
+// It is not a faithful reimplementation of Dependency-Track: BOMs are not parsed, and no vulnerability
+// analysis is performed. Findings and policy violations must be seeded explicitly
+// using Server.AddFinding and Server.AddPolicyViolation.
+package dtracktest
@@ -0,0 +1,1422 @@
+package dtracktest
+
+import (
+	"cmp"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+)
+
+func (s *Server) handleVersion(w http.ResponseWriter, _ *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	writeJSON(w, http.StatusOK, dtrack.About{
+		Application: "Dependency-Track",
+		Version:     s.version,
+	})
+}
+
+// projectSortFields are the fields projects can be sorted by.
+var projectSortFields = map[string]func(a, b dtrack.Project) int{
+	"name":          func(a, b dtrack.Project) int { return strings.Compare(a.Name, b.Name) },
+	"version":       func(a, b dtrack.Project) int { return strings.Compare(a.Version, b.Version) },
+	"classifier":    func(a, b dtrack.Project) int { return strings.Compare(a.Classifier, b.Classifier) },
+	"lastBomImport": func(a, b dtrack.Project) int { return a.LastBOMImport - b.LastBOMImport },
+}
+
+func (s *Server) handleGetProjects(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+
+	s.listProjects(w, r, func(p dtrack.Project) bool {
+		return name == "" || p.Name == name
+	})
+}
+
+func (s *Server) handleGetProjectsByTag(w http.ResponseWriter, r *http.Request) {
+	tag := r.PathValue("tag")
+
+	s.listProjects(w, r, func(p dtrack.Project) bool {
+		for _, t := range p.Tags {
+			if t.Name == tag {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (s *Server) handleGetProjectsByClassifier(w http.ResponseWriter, r *http.Request) {
+	classifier := r.PathValue("classifier")
+
+	s.listProjects(w, r, func(p dtrack.Project) bool {
+		return strings.EqualFold(p.Classifier, classifier)
+	})
+}
+
+// listProjects responds with the projects matching match, filtered and sorted according to the query parameters.
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, match func(p dtrack.Project) bool) {
+	query := r.URL.Query()
+	searchText := strings.ToLower(query.Get("searchText"))
+	excludeInactive, _ := strconv.ParseBool(query.Get("excludeInactive"))
+	onlyRoot, _ := strconv.ParseBool(query.Get("onlyRoot"))
+
+	var notAssignedToTeam uuid.UUID
+	if value := query.Get("notAssignedToTeamWithUuid"); value != "" {
+		var err error
+		if notAssignedToTeam, err = uuid.Parse(value); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid UUID")
+			return
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	projects := make([]dtrack.Project, 0)
+	for _, p := range s.sortedProjects() {
+		if !match(p) {
+			continue
+		}
+		if searchText != "" && !strings.Contains(strings.ToLower(p.Name), searchText) {
+			continue
+		}
+		if excludeInactive && !p.Active {
+			continue
+		}
+		if onlyRoot && p.ParentRef != nil {
+			continue
+		}
+		if notAssignedToTeam != uuid.Nil {
+			if _, assigned := s.aclMappings[dtrack.ACLMapping{Team: notAssignedToTeam, Project: p.UUID}]; assigned {
+				continue
+			}
+		}
+		projects = append(projects, p)
+	}
+	if !sortItems(w, r, projects, projectSortFields) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, paginate(w, r, projects))
+}
+
+func (s *Server) handleGetProject(w http.ResponseWriter, r *http.Request) {
+	projectUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	project, ok := s.projects[projectUUID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) handleGetProjectRelation(w http.ResponseWriter, r *http.Request) {
+	switch r.PathValue("relation") {
+	case "children":
+		s.handleGetProjectChildren(w, r)
+	case "property":
+		s.handleGetProjectProperties(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) handleGetProjectChildren(w http.ResponseWriter, r *http.Request) {
+	projectUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.projects[projectUUID]; !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+
+	children := make([]dtrack.Project, 0)
+	for _, p := range s.sortedProjects() {
+		if p.ParentRef != nil && p.ParentRef.UUID == projectUUID {
+			children = append(children, p)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, paginate(w, r, children))
+}
+
+func (s *Server) handleLookupProject(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	project, ok := s.findProject(r.URL.Query().Get("name"), r.URL.Query().Get("version"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) handleCreateProject(w http.ResponseWriter, r *http.Request) {
+	var project dtrack.Project
+	if !decodeBody(w, r, &project) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.findProject(project.Name, project.Version); exists {
+		writeError(w, http.StatusConflict, "A project with the specified name already exists.")
+		return
+	}
+
+	project.UUID = uuid.New()
+	s.projects[project.UUID] = project
+
+	writeJSON(w, http.StatusCreated, project)
+}
+
+func (s *Server) handleUpdateProject(w http.ResponseWriter, r *http.Request) {
+	var project dtrack.Project
+	if !decodeBody(w, r, &project) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.projects[project.UUID]; !ok {
+		writeError(w, http.StatusNotFound, "The UUID of the project could not be found.")
+		return
+	}
+	if existing, exists := s.findProject(project.Name, project.Version); exists && existing.UUID != project.UUID {
+		writeError(w, http.StatusConflict, "A project with the specified name and version already exists.")
+		return
+	}
+
+	s.projects[project.UUID] = project
+
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) handlePatchProject(w http.ResponseWriter, r *http.Request) {
+	projectUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	var patch map[string]json.RawMessage
+	if !decodeBody(w, r, &patch) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	project, ok := s.projects[projectUUID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+
+	// Overlay the fields present in the patch onto the existing project.
+	// Fields that can't be modified through the API are ignored.
+	var fields map[string]json.RawMessage
+	existing, _ := json.Marshal(project)
+	_ = json.Unmarshal(existing, &fields)
+	for k, v := range patch {
+		switch k {
+		case "uuid", "metrics", "lastBomImport":
+			continue
+		}
+		fields[k] = v
+	}
+
+	merged, _ := json.Marshal(fields)
+	var patched dtrack.Project
+	if err := json.Unmarshal(merged, &patched); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	patched.UUID = projectUUID
+
+	if existing, exists := s.findProject(patched.Name, patched.Version); exists && existing.UUID != projectUUID {
+		writeError(w, http.StatusConflict, "A project with the specified name and version already exists.")
+		return
+	}
+
+	s.projects[projectUUID] = patched
+
+	writeJSON(w, http.StatusOK, patched)
+}
+
+func (s *Server) handleDeleteProject(w http.ResponseWriter, r *http.Request) {
+	projectUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.projects[projectUUID]; !ok {
+		writeError(w, http.StatusNotFound, "The UUID of the project could not be found.")
+		return
+	}
+
+	delete(s.projects, projectUUID)
+	delete(s.properties, projectUUID)
+	delete(s.findings, projectUUID)
+	delete(s.violations, projectUUID)
+	for componentUUID, c := range s.components {
+		if c.projectUUID == projectUUID {
+			delete(s.components, componentUUID)
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleGetProjectProperties(w http.ResponseWriter, r *http.Request) {
+	projectUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.projects[projectUUID]; !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, paginate(w, r, append([]dtrack.ProjectProperty{}, s.properties[projectUUID]...)))
+}
+
+func (s *Server) handleCreateProjectProperty(w http.ResponseWriter, r *http.Request) {
+	projectUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	var property dtrack.ProjectProperty
+	if !decodeBody(w, r, &property) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.projects[projectUUID]; !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+	if s.findProjectProperty(projectUUID, property.Group, property.Name) >= 0 {
+		writeError(w, http.StatusConflict, "A property with the specified project/group/name combination already exists.")
+		return
+	}
+
+	s.properties[projectUUID] = append(s.properties[projectUUID], property)
+
+	writeJSON(w, http.StatusCreated, property)
+}
+
+func (s *Server) handleUpdateProjectProperty(w http.ResponseWriter, r *http.Request) {
+	projectUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	var property dtrack.ProjectProperty
+	if !decodeBody(w, r, &property) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.projects[projectUUID]; !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+
+	i := s.findProjectProperty(projectUUID, property.Group, property.Name)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "A property with the specified project/group/name combination could not be found.")
+		return
+	}
+
+	stored := &s.properties[projectUUID][i]
+	stored.Value = property.Value
+	if property.Description != "" {
+		stored.Description = property.Description
+	}
+
+	writeJSON(w, http.StatusOK, *stored)
+}
+
+func (s *Server) handleDeleteProjectProperty(w http.ResponseWriter, r *http.Request) {
+	projectUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	var property dtrack.ProjectProperty
+	if !decodeBody(w, r, &property) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	i := s.findProjectProperty(projectUUID, property.Group, property.Name)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "A property with the specified project/group/name combination could not be found.")
+		return
+	}
+
+	s.properties[projectUUID] = append(s.properties[projectUUID][:i], s.properties[projectUUID][i+1:]...)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// findProjectProperty returns the index of a property of a project, or -1 if it doesn't exist.
+func (s *Server) findProjectProperty(projectUUID uuid.UUID, group, name string) int {
+	for i, p := range s.properties[projectUUID] {
+		if p.Group == group && p.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *Server) handleCloneProject(w http.ResponseWriter, r *http.Request) {
+	var cloneReq dtrack.ProjectCloneRequest
+	if !decodeBody(w, r, &cloneReq) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	source, ok := s.projects[cloneReq.ProjectUUID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+	if _, exists := s.findProject(source.Name, cloneReq.Version); exists {
+		writeError(w, http.StatusConflict, "A project with the specified name and version already exists.")
+		return
+	}
+
+	// Like Dependency-Track, the clone is created right away, but only populated once its token completed.
+	clone := s.createClone(source, cloneReq)
+
+	token := s.newToken()
+	s.pendingClones[token] = pendingClone{request: cloneReq, cloneUUID: clone.UUID}
+	if s.tokens[token] == 0 {
+		s.pollToken(token)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"token": token})
+}
+
+// pendingClone is a clone whose components and properties have not been copied yet.
+type pendingClone struct {
+	request   dtrack.ProjectCloneRequest
+	cloneUUID uuid.UUID
+}
+
+// createClone creates the project of a clone of source.
+func (s *Server) createClone(source dtrack.Project, cloneReq dtrack.ProjectCloneRequest) dtrack.Project {
+	clone := source
+	clone.UUID = uuid.New()
+	clone.Version = cloneReq.Version
+	clone.Active = true
+	clone.Metrics = dtrack.ProjectMetrics{}
+	clone.LastBOMImport = 0
+	clone.IsLatest = cloneReq.MakeCloneLatest
+	if !cloneReq.IncludeTags {
+		clone.Tags = nil
+	}
+
+	if cloneReq.MakeCloneLatest {
+		for _, p := range s.projects {
+			if p.Name == clone.Name && p.IsLatest {
+				p.IsLatest = false
+				s.projects[p.UUID] = p
+			}
+		}
+	}
+	s.projects[clone.UUID] = clone
+
+	return clone
+}
+
+// completeClone copies the components and properties of a pending clone.
+func (s *Server) completeClone(pending pendingClone) {
+	sourceUUID := pending.request.ProjectUUID
+	if _, ok := s.projects[pending.cloneUUID]; !ok {
+		return
+	}
+
+	if pending.request.IncludeProperties {
+		s.properties[pending.cloneUUID] = append([]dtrack.ProjectProperty(nil), s.properties[sourceUUID]...)
+	}
+	if pending.request.IncludeComponents {
+		for _, c := range s.components {
+			if c.projectUUID == sourceUUID {
+				component := c.component
+				component.UUID = uuid.New()
+				s.components[component.UUID] = storedComponent{projectUUID: pending.cloneUUID, component: component}
+			}
+		}
+	}
+}
+
+func (s *Server) findProject(name, version string) (dtrack.Project, bool) {
+	for _, p := range s.projects {
+		if p.Name == name && p.Version == version {
+			return p, true
+		}
+	}
+
+	return dtrack.Project{}, false
+}
+
+func (s *Server) handleGetComponent(w http.ResponseWriter, r *http.Request) {
+	componentUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	c, ok := s.components[componentUUID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The component could not be found.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, c.component)
+}
+
+// componentSortFields are the fields components can be sorted by.
+var componentSortFields = map[string]func(a, b dtrack.Component) int{
+	"group":   func(a, b dtrack.Component) int { return strings.Compare(a.Group, b.Group) },
+	"name":    func(a, b dtrack.Component) int { return strings.Compare(a.Name, b.Name) },
+	"version": func(a, b dtrack.Component) int { return strings.Compare(a.Version, b.Version) },
+}
+
+func (s *Server) handleGetComponents(w http.ResponseWriter, r *http.Request) {
+	projectUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+	// Components don't carry repository metadata, so whether they are outdated is unknown.
+	if rejectParams(w, r, "onlyOutdated") {
+		return
+	}
+
+	query := r.URL.Query()
+	searchText := strings.ToLower(query.Get("searchText"))
+	onlyDirect, _ := strconv.ParseBool(query.Get("onlyDirect"))
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	project, ok := s.projects[projectUUID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+
+	direct := make(map[uuid.UUID]bool)
+	if onlyDirect && project.DirectDependencies != "" {
+		var refs []struct {
+			UUID uuid.UUID `json:"uuid"`
+		}
+		if err := json.Unmarshal([]byte(project.DirectDependencies), &refs); err != nil {
+			writeError(w, http.StatusInternalServerError, "Invalid direct dependencies of project")
+			return
+		}
+		for _, ref := range refs {
+			direct[ref.UUID] = true
+		}
+	}
+
+	components := make([]dtrack.Component, 0)
+	for _, c := range s.components {
+		if c.projectUUID != projectUUID {
+			continue
+		}
+		if searchText != "" && !strings.Contains(strings.ToLower(c.component.Name), searchText) {
+			continue
+		}
+		if onlyDirect && !direct[c.component.UUID] {
+			continue
+		}
+		components = append(components, c.component)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		if components[i].Name != components[j].Name {
+			return components[i].Name < components[j].Name
+		}
+		return components[i].Version < components[j].Version
+	})
+	if !sortItems(w, r, components, componentSortFields) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, paginate(w, r, components))
+}
+
+func (s *Server) handleCreateComponent(w http.ResponseWriter, r *http.Request) {
+	projectUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	var component dtrack.Component
+	if !decodeBody(w, r, &component) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.projects[projectUUID]; !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+
+	component.UUID = uuid.New()
+	s.components[component.UUID] = storedComponent{projectUUID: projectUUID, component: component}
+
+	writeJSON(w, http.StatusCreated, component)
+}
+
+func (s *Server) handleUpdateComponent(w http.ResponseWriter, r *http.Request) {
+	var component dtrack.Component
+	if !decodeBody(w, r, &component) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	c, ok := s.components[component.UUID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The UUID of the component could not be found.")
+		return
+	}
+
+	c.component = component
+	s.components[component.UUID] = c
+
+	writeJSON(w, http.StatusOK, component)
+}
+
+// findingSortFields are the fields findings can be sorted by.
+// Like in Dependency-Track, severities are sorted from the least to the most severe in ascending order.
+var findingSortFields = map[string]func(a, b dtrack.Finding) int{
+	"vulnerability.vulnId":     func(a, b dtrack.Finding) int { return strings.Compare(a.Vulnerability.VulnID, b.Vulnerability.VulnID) },
+	"vulnerability.severity":   func(a, b dtrack.Finding) int { return findingSeverity(b).Rank() - findingSeverity(a).Rank() },
+	"component.name":           func(a, b dtrack.Finding) int { return strings.Compare(a.Component.Name, b.Component.Name) },
+	"component.version":        func(a, b dtrack.Finding) int { return strings.Compare(a.Component.Version, b.Component.Version) },
+	"attribution.attributedOn": func(a, b dtrack.Finding) int { return a.Attribution.AttributedOn - b.Attribution.AttributedOn },
+}
+
+func findingSeverity(f dtrack.Finding) dtrack.Severity {
+	return dtrack.Severity(strings.ToUpper(f.Vulnerability.Severity))
+}
+
+// matchesFinding reports whether the vulnerability or component of f contain searchText, which must be lower case.
+func matchesFinding(f dtrack.Finding, searchText string) bool {
+	for _, field := range []string{f.Vulnerability.VulnID, f.Vulnerability.Title, f.Component.Name, f.Component.Group} {
+		if strings.Contains(strings.ToLower(field), searchText) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) handleGetFindings(w http.ResponseWriter, r *http.Request) {
+	projectUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	suppressed, _ := strconv.ParseBool(query.Get("suppressed"))
+	source := query.Get("source")
+	searchText := strings.ToLower(query.Get("searchText"))
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.projects[projectUUID]; !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+
+	findings := make([]dtrack.Finding, 0)
+	for _, f := range s.findings[projectUUID] {
+		if f.Analysis.Suppressed && !suppressed {
+			continue
+		}
+		if source != "" && !strings.EqualFold(f.Vulnerability.Source, source) {
+			continue
+		}
+		if searchText != "" && !matchesFinding(f, searchText) {
+			continue
+		}
+		findings = append(findings, f)
+	}
+	if !sortItems(w, r, findings, findingSortFields) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, paginate(w, r, findings))
+}
+
+func (s *Server) handleGetPortfolioFindings(w http.ResponseWriter, r *http.Request) {
+	findings, ok := s.portfolioFindings(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, paginate(w, r, findings))
+}
+
+func (s *Server) handleGetGroupedFindings(w http.ResponseWriter, r *http.Request) {
+	findings, ok := s.portfolioFindings(w, r)
+	if !ok {
+		return
+	}
+
+	grouped := make([]dtrack.Finding, 0)
+	indexes := make(map[string]int)
+	projects := make(map[string]map[uuid.UUID]struct{})
+	for _, f := range findings {
+		key := f.Vulnerability.Source + "|" + f.Vulnerability.VulnID
+		if _, ok := indexes[key]; !ok {
+			indexes[key] = len(grouped)
+			projects[key] = make(map[uuid.UUID]struct{})
+			grouped = append(grouped, dtrack.Finding{Attribution: f.Attribution, Vulnerability: f.Vulnerability})
+		}
+		projects[key][f.Component.Project] = struct{}{}
+		grouped[indexes[key]].Vulnerability.AffectedProjectCount = len(projects[key])
+	}
+
+	writeJSON(w, http.StatusOK, paginate(w, r, grouped))
+}
+
+// portfolioFindings returns the findings of all projects matching the query parameters, sorted according to them.
+func (s *Server) portfolioFindings(w http.ResponseWriter, r *http.Request) ([]dtrack.Finding, bool) {
+	if rejectParams(w, r, "publishDateFrom", "publishDateTo", "attributedOnDateFrom", "attributedOnDateTo", "textSearchInput", "textSearchField") {
+		return nil, false
+	}
+
+	query := r.URL.Query()
+	severities := splitParam(query.Get("severity"))
+	analysisStates := splitParam(query.Get("analysisStatus"))
+	showInactive, _ := strconv.ParseBool(query.Get("showInactive"))
+	showSuppressed, _ := strconv.ParseBool(query.Get("showSuppressed"))
+	source := query.Get("source")
+
+	cvssV2, ok := parseScoreRange(w, query, "cvssv2")
+	if !ok {
+		return nil, false
+	}
+	cvssV3, ok := parseScoreRange(w, query, "cvssv3")
+	if !ok {
+		return nil, false
+	}
+	epss, ok := parseScoreRange(w, query, "epss")
+	if !ok {
+		return nil, false
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	findings := make([]dtrack.Finding, 0)
+	for _, p := range s.sortedProjects() {
+		if !p.Active && !showInactive {
+			continue
+		}
+
+		for _, f := range s.findings[p.UUID] {
+			if f.Analysis.Suppressed && !showSuppressed {
+				continue
+			}
+			if len(severities) > 0 && !slices.Contains(severities, string(findingSeverity(f))) {
+				continue
+			}
+			state := cmp.Or(f.Analysis.State, string(dtrack.AnalysisStateNotSet))
+			if len(analysisStates) > 0 && !slices.Contains(analysisStates, state) {
+				continue
+			}
+			if source != "" && !strings.EqualFold(f.Vulnerability.Source, source) {
+				continue
+			}
+			if !cvssV2.contains(f.Vulnerability.CVSSV2BaseScore) || !cvssV3.contains(f.Vulnerability.CVSSV3BaseScore) || !epss.contains(f.Vulnerability.EPSSScore) {
+				continue
+			}
+
+			f.Component.ProjectName = p.Name
+			f.Component.ProjectVersion = p.Version
+			findings = append(findings, f)
+		}
+	}
+	if !sortItems(w, r, findings, findingSortFields) {
+		return nil, false
+	}
+
+	return findings, true
+}
+
+// scoreRange is an inclusive range of scores. Bounds that are zero are not applied.
+type scoreRange struct {
+	from float64
+	to   float64
+}
+
+func (sr scoreRange) contains(score float64) bool {
+	return (sr.from == 0 || score >= sr.from) && (sr.to == 0 || score <= sr.to)
+}
+
+// parseScoreRange parses the <param>From and <param>To query parameters.
+func parseScoreRange(w http.ResponseWriter, query url.Values, param string) (sr scoreRange, ok bool) {
+	var err error
+	if value := query.Get(param + "From"); value != "" {
+		if sr.from, err = strconv.ParseFloat(value, 64); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid "+param+"From")
+			return scoreRange{}, false
+		}
+	}
+	if value := query.Get(param + "To"); value != "" {
+		if sr.to, err = strconv.ParseFloat(value, 64); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid "+param+"To")
+			return scoreRange{}, false
+		}
+	}
+
+	return sr, true
+}
+
+// splitParam splits a comma separated query parameter, returning nil if it is empty.
+func splitParam(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, ",")
+}
+
+func (s *Server) handleGetProjectViolations(w http.ResponseWriter, r *http.Request) {
+	projectUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	suppressed, _ := strconv.ParseBool(r.URL.Query().Get("suppressed"))
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.projects[projectUUID]; !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+
+	violations := make([]dtrack.PolicyViolation, 0)
+	for _, v := range s.violations[projectUUID] {
+		if v.Analysis != nil && v.Analysis.Suppressed && !suppressed {
+			continue
+		}
+		violations = append(violations, v)
+	}
+
+	writeJSON(w, http.StatusOK, paginate(w, r, violations))
+}
+
+func (s *Server) handleAnalyzeProject(w http.ResponseWriter, r *http.Request) {
+	projectUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.projects[projectUUID]; !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"token": s.newToken()})
+}
+
+func (s *Server) handleGetAnalysis(w http.ResponseWriter, r *http.Request) {
+	var key analysisKey
+	for param, id := range map[string]*uuid.UUID{
+		"component":     &key.component,
+		"project":       &key.project,
+		"vulnerability": &key.vulnerability,
+	} {
+		parsed, err := uuid.Parse(r.URL.Query().Get(param))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid "+param+" UUID")
+			return
+		}
+		*id = parsed
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	analysis, ok := s.analyses[key]
+	if !ok {
+		writeError(w, http.StatusNotFound, "No analysis exists.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, analysis)
+}
+
+func (s *Server) handleUpdateAnalysis(w http.ResponseWriter, r *http.Request) {
+	var analysisReq dtrack.AnalysisRequest
+	if !decodeBody(w, r, &analysisReq) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := analysisKey{
+		component:     analysisReq.Component,
+		project:       analysisReq.Project,
+		vulnerability: analysisReq.Vulnerability,
+	}
+
+	analysis, ok := s.analyses[key]
+	if !ok {
+		analysis = dtrack.Analysis{
+			State:         dtrack.AnalysisStateNotSet,
+			Justification: dtrack.AnalysisJustificationNotSet,
+			Response:      dtrack.AnalysisResponseNotSet,
+		}
+	}
+	if analysisReq.State != "" {
+		analysis.State = analysisReq.State
+	}
+	if analysisReq.Justification != "" {
+		analysis.Justification = analysisReq.Justification
+	}
+	if analysisReq.Response != "" {
+		analysis.Response = analysisReq.Response
+	}
+	if analysisReq.Details != "" {
+		analysis.Details = analysisReq.Details
+	}
+	if analysisReq.Suppressed != nil {
+		analysis.Suppressed = *analysisReq.Suppressed
+	}
+	if analysisReq.Comment != "" {
+		analysis.Comments = append(analysis.Comments, dtrack.AnalysisComment{Comment: analysisReq.Comment})
+	}
+	s.analyses[key] = analysis
+
+	// Reflect the analysis in the corresponding finding.
+	for i, f := range s.findings[key.project] {
+		if f.Component.UUID == key.component && f.Vulnerability.UUID == key.vulnerability {
+			s.findings[key.project][i].Analysis = dtrack.FindingAnalysis{
+				State:      string(analysis.State),
+				Suppressed: analysis.Suppressed,
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, analysis)
+}
+
+func (s *Server) handleGetPolicies(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	policies := make([]dtrack.Policy, 0, len(s.policies))
+	for _, p := range s.policies {
+		policies = append(policies, p)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+
+	writeJSON(w, http.StatusOK, paginate(w, r, policies))
+}
+
+func (s *Server) handleGetPolicy(w http.ResponseWriter, r *http.Request) {
+	policyUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	policy, ok := s.policies[policyUUID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The policy could not be found.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, policy)
+}
+
+func (s *Server) handleCreatePolicy(w http.ResponseWriter, r *http.Request) {
+	var policy dtrack.Policy
+	if !decodeBody(w, r, &policy) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, p := range s.policies {
+		if p.Name == policy.Name {
+			writeError(w, http.StatusConflict, "A policy with the specified name already exists.")
+			return
+		}
+	}
+
+	policy.UUID = uuid.New()
+	s.policies[policy.UUID] = policy
+
+	writeJSON(w, http.StatusCreated, policy)
+}
+
+func (s *Server) handleUpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	var policy dtrack.Policy
+	if !decodeBody(w, r, &policy) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.policies[policy.UUID]; !ok {
+		writeError(w, http.StatusNotFound, "The policy could not be found.")
+		return
+	}
+
+	s.policies[policy.UUID] = policy
+
+	writeJSON(w, http.StatusOK, policy)
+}
+
+func (s *Server) handleDeletePolicy(w http.ResponseWriter, r *http.Request) {
+	policyUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.policies[policyUUID]; !ok {
+		writeError(w, http.StatusNotFound, "The policy could not be found.")
+		return
+	}
+
+	delete(s.policies, policyUUID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleGetTeams(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	teams := make([]dtrack.Team, 0, len(s.teams))
+	for _, t := range s.teams {
+		teams = append(teams, t)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].Name < teams[j].Name
+	})
+
+	writeJSON(w, http.StatusOK, paginate(w, r, teams))
+}
+
+func (s *Server) handleGetTeam(w http.ResponseWriter, r *http.Request) {
+	teamUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	team, ok := s.teams[teamUUID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The team could not be found.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, team)
+}
+
+func (s *Server) handleCreateTeam(w http.ResponseWriter, r *http.Request) {
+	var team dtrack.Team
+	if !decodeBody(w, r, &team) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	team.UUID = uuid.New()
+	s.teams[team.UUID] = team
+
+	writeJSON(w, http.StatusCreated, team)
+}
+
+func (s *Server) handleUpdateTeam(w http.ResponseWriter, r *http.Request) {
+	var team dtrack.Team
+	if !decodeBody(w, r, &team) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, ok := s.teams[team.UUID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The team could not be found.")
+		return
+	}
+
+	// Like Dependency-Track, only the name of a team can be updated this way.
+	existing.Name = team.Name
+	s.teams[team.UUID] = existing
+
+	writeJSON(w, http.StatusOK, existing)
+}
+
+func (s *Server) handleDeleteTeam(w http.ResponseWriter, r *http.Request) {
+	var team dtrack.Team
+	if !decodeBody(w, r, &team) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.teams[team.UUID]; !ok {
+		writeError(w, http.StatusNotFound, "The team could not be found.")
+		return
+	}
+
+	delete(s.teams, team.UUID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleUploadBOM(w http.ResponseWriter, r *http.Request) {
+	var uploadReq dtrack.BOMUploadRequest
+	if !decodeBody(w, r, &uploadReq) {
+		return
+	}
+
+	s.acceptBOM(w, uploadReq)
+}
+
+func (s *Server) handlePostBOM(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid gzip body")
+			return
+		}
+		r.Body = gzipReader
+	}
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid multipart request")
+		return
+	}
+
+	uploadReq := dtrack.BOMUploadRequest{
+		ProjectName:    r.FormValue("projectName"),
+		ProjectVersion: r.FormValue("projectVersion"),
+		ParentName:     r.FormValue("parentName"),
+		ParentVersion:  r.FormValue("parentVersion"),
+		AutoCreate:     r.FormValue("autoCreate") == "true",
+		BOM:            r.FormValue("bom"),
+	}
+	for field, id := range map[string]**uuid.UUID{
+		"project":    &uploadReq.ProjectUUID,
+		"parentUUID": &uploadReq.ParentUUID,
+	} {
+		if value := r.FormValue(field); value != "" {
+			parsed, err := uuid.Parse(value)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "Invalid "+field)
+				return
+			}
+			*id = &parsed
+		}
+	}
+
+	// The BOM may be provided as a file instead of a plain field.
+	if file, _, err := r.FormFile("bom"); err == nil {
+		content, err := io.ReadAll(file)
+		_ = file.Close()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid BOM")
+			return
+		}
+		uploadReq.BOM = string(content)
+	}
+
+	// The multipart endpoint accepts the BOM in plain text, while the JSON endpoint expects base64.
+	uploadReq.BOM = base64.StdEncoding.EncodeToString([]byte(uploadReq.BOM))
+
+	s.acceptBOM(w, uploadReq)
+}
+
+func (s *Server) acceptBOM(w http.ResponseWriter, uploadReq dtrack.BOMUploadRequest) {
+	bom, err := base64.StdEncoding.DecodeString(uploadReq.BOM)
+	if err != nil || len(bom) == 0 {
+		writeError(w, http.StatusBadRequest, "Invalid BOM")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var (
+		project dtrack.Project
+		found   bool
+	)
+	if uploadReq.ProjectUUID != nil {
+		project, found = s.projects[*uploadReq.ProjectUUID]
+	} else {
+		project, found = s.findProject(uploadReq.ProjectName, uploadReq.ProjectVersion)
+	}
+
+	if !found {
+		if !uploadReq.AutoCreate || uploadReq.ProjectName == "" {
+			writeError(w, http.StatusNotFound, "The project could not be found.")
+			return
+		}
+
+		project = dtrack.Project{
+			UUID:    uuid.New(),
+			Name:    uploadReq.ProjectName,
+			Version: uploadReq.ProjectVersion,
+			Active:  true,
+		}
+
+		if uploadReq.ParentUUID != nil || uploadReq.ParentName != "" {
+			var (
+				parent      dtrack.Project
+				parentFound bool
+			)
+			if uploadReq.ParentUUID != nil {
+				parent, parentFound = s.projects[*uploadReq.ParentUUID]
+			} else {
+				parent, parentFound = s.findProject(uploadReq.ParentName, uploadReq.ParentVersion)
+			}
+			if !parentFound {
+				writeError(w, http.StatusNotFound, "The parent component could not be found.")
+				return
+			}
+			project.ParentRef = &dtrack.ParentRef{UUID: parent.UUID}
+		}
+
+		s.projects[project.UUID] = project
+	}
+
+	token := s.newToken()
+	s.bomUploads = append(s.bomUploads, BOMUpload{
+		Token:       token,
+		ProjectUUID: project.UUID,
+		BOM:         bom,
+	})
+
+	writeJSON(w, http.StatusOK, map[string]string{"token": token})
+}
+
+func (s *Server) handleUploadVEX(w http.ResponseWriter, r *http.Request) {
+	var uploadReq dtrack.VEXUploadRequest
+	if !decodeBody(w, r, &uploadReq) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var found bool
+	if uploadReq.ProjectUUID != nil {
+		_, found = s.projects[*uploadReq.ProjectUUID]
+	} else {
+		_, found = s.findProject(uploadReq.ProjectName, uploadReq.ProjectVersion)
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"token": s.newToken()})
+}
+
+func (s *Server) handleGetToken(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]bool{"processing": s.pollToken(r.PathValue("token"))})
+}
+
+// newToken issues a token for an asynchronous task.
+func (s *Server) newToken() string {
+	token := uuid.New().String()
+	s.tokens[token] = s.processingPolls
+
+	return token
+}
+
+// pollToken reports whether the task identified by token is still being processed.
+// Unknown tokens are reported as not being processed, like Dependency-Track does.
+func (s *Server) pollToken(token string) bool {
+	remaining, ok := s.tokens[token]
+	if !ok || remaining <= 0 {
+		delete(s.tokens, token)
+		if pending, ok := s.pendingClones[token]; ok {
+			delete(s.pendingClones, token)
+			s.completeClone(pending)
+		}
+		return false
+	}
+
+	s.tokens[token] = remaining - 1
+	return true
+}
+
+func (s *Server) handleRefreshPortfolioMetrics(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleRefreshProjectMetrics(w http.ResponseWriter, r *http.Request) {
+	projectUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.projects[projectUUID]; !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+
+	s.refreshes = append(s.refreshes, projectUUID)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleGetACLProjects(w http.ResponseWriter, r *http.Request) {
+	teamUUID, ok := pathUUID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.teams[teamUUID]; !ok {
+		writeError(w, http.StatusNotFound, "The team could not be found.")
+		return
+	}
+
+	projects := make([]dtrack.Project, 0)
+	for _, p := range s.sortedProjects() {
+		if _, ok := s.aclMappings[dtrack.ACLMapping{Team: teamUUID, Project: p.UUID}]; ok {
+			projects = append(projects, p)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, projects)
+}
+
+func (s *Server) handleCreateACLMapping(w http.ResponseWriter, r *http.Request) {
+	var mapping dtrack.ACLMapping
+	if !decodeBody(w, r, &mapping) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.teams[mapping.Team]; !ok {
+		writeError(w, http.StatusNotFound, "The team could not be found.")
+		return
+	}
+	if _, ok := s.projects[mapping.Project]; !ok {
+		writeError(w, http.StatusNotFound, "The project could not be found.")
+		return
+	}
+	if _, ok := s.aclMappings[mapping]; ok {
+		writeError(w, http.StatusConflict, "A mapping with the same team and project already exists.")
+		return
+	}
+
+	s.aclMappings[mapping] = struct{}{}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleDeleteACLMapping(w http.ResponseWriter, r *http.Request) {
+	teamUUID, err := uuid.Parse(r.PathValue("team"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+	projectUUID, err := uuid.Parse(r.PathValue("project"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.aclMappings, dtrack.ACLMapping{Team: teamUUID, Project: projectUUID})
+	w.WriteHeader(http.StatusOK)
+}
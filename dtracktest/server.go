@@ -0,0 +1,513 @@
+package dtracktest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+)
+
+// DefaultVersion is the Dependency-Track version reported by the fake server.
+const DefaultVersion = "4.11.0"
+
+// Server is an in-memory fake of the Dependency-Track API, backed by an httptest.Server.
+type Server struct {
+	server *httptest.Server
+
+	mutex           sync.Mutex
+	version         string
+	apiKeys         map[string]struct{}
+	projects        map[uuid.UUID]dtrack.Project
+	properties      map[uuid.UUID][]dtrack.ProjectProperty
+	components      map[uuid.UUID]storedComponent
+	findings        map[uuid.UUID][]dtrack.Finding
+	violations      map[uuid.UUID][]dtrack.PolicyViolation
+	analyses        map[analysisKey]dtrack.Analysis
+	policies        map[uuid.UUID]dtrack.Policy
+	teams           map[uuid.UUID]dtrack.Team
+	aclMappings     map[dtrack.ACLMapping]struct{}
+	tokens          map[string]int          // Remaining number of polls until processing completes
+	pendingClones   map[string]pendingClone // Clones to populate once processing of their token completes
+	processingPolls int
+	bomUploads      []BOMUpload
+	refreshes       []uuid.UUID // Projects whose metrics were refreshed
+	failures        []*Failure
+}
+
+type storedComponent struct {
+	projectUUID uuid.UUID
+	component   dtrack.Component
+}
+
+type analysisKey struct {
+	component     uuid.UUID
+	project       uuid.UUID
+	vulnerability uuid.UUID
+}
+
+// BOMUpload records a BOM that was uploaded to the server.
+type BOMUpload struct {
+	Token       string
+	ProjectUUID uuid.UUID
+	BOM         []byte
+}
+
+// Failure describes a failure to be injected into responses of the server.
+type Failure struct {
+	Method     string // HTTP method to fail, or empty for all methods
+	PathPrefix string // Prefix of paths to fail, e.g. /api/v1/project
+	StatusCode int    // Status code to respond with
+	Body       string // Body to respond with
+	Times      int    // Number of times to fail, or 0 to fail indefinitely
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithAPIKey requires clients to authenticate with apiKey, either via the X-Api-Key header
+// or as bearer token. It may be used multiple times to allow multiple keys.
+func WithAPIKey(apiKey string) Option {
+	return func(s *Server) {
+		s.apiKeys[apiKey] = struct{}{}
+	}
+}
+
+// WithVersion overrides the Dependency-Track version reported by the server.
+func WithVersion(version string) Option {
+	return func(s *Server) {
+		s.version = version
+	}
+}
+
+// WithProcessingPolls sets the number of times the status of a token, e.g. of a BOM upload,
+// is reported as processing, before processing is reported as complete.
+// Cloned projects are created right away, but their components and properties are only copied
+// once processing of their token completed.
+func WithProcessingPolls(polls int) Option {
+	return func(s *Server) {
+		s.processingPolls = polls
+	}
+}
+
+// NewServer starts a new fake Dependency-Track server.
+// It must be closed using Close when no longer needed.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		version:       DefaultVersion,
+		apiKeys:       make(map[string]struct{}),
+		projects:      make(map[uuid.UUID]dtrack.Project),
+		properties:    make(map[uuid.UUID][]dtrack.ProjectProperty),
+		components:    make(map[uuid.UUID]storedComponent),
+		findings:      make(map[uuid.UUID][]dtrack.Finding),
+		violations:    make(map[uuid.UUID][]dtrack.PolicyViolation),
+		analyses:      make(map[analysisKey]dtrack.Analysis),
+		policies:      make(map[uuid.UUID]dtrack.Policy),
+		teams:         make(map[uuid.UUID]dtrack.Team),
+		aclMappings:   make(map[dtrack.ACLMapping]struct{}),
+		tokens:        make(map[string]int),
+		pendingClones: make(map[string]pendingClone),
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	s.server = httptest.NewServer(s.routes())
+	return s
+}
+
+// URL returns the base URL of the server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client creates a dtrack.Client for the server. If the server requires authentication,
+// the client is configured with one of the server's API keys.
+func (s *Server) Client(options ...dtrack.ClientOption) (*dtrack.Client, error) {
+	s.mutex.Lock()
+	for apiKey := range s.apiKeys {
+		options = append([]dtrack.ClientOption{dtrack.WithAPIKey(apiKey)}, options...)
+		break
+	}
+	s.mutex.Unlock()
+
+	return dtrack.NewClient(s.URL(), options...)
+}
+
+// InjectFailure makes the server fail requests matching f.
+// Failures are evaluated in the order they were injected.
+func (s *Server) InjectFailure(f Failure) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.failures = append(s.failures, &f)
+}
+
+// AddProject seeds a project. A UUID is assigned if p does not have one.
+func (s *Server) AddProject(p dtrack.Project) dtrack.Project {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if p.UUID == uuid.Nil {
+		p.UUID = uuid.New()
+	}
+	s.projects[p.UUID] = p
+
+	return p
+}
+
+// AddComponent seeds a component of a project. A UUID is assigned if c does not have one.
+func (s *Server) AddComponent(projectUUID uuid.UUID, c dtrack.Component) dtrack.Component {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if c.UUID == uuid.Nil {
+		c.UUID = uuid.New()
+	}
+	s.components[c.UUID] = storedComponent{projectUUID: projectUUID, component: c}
+
+	return c
+}
+
+// AddFinding seeds a finding of a project.
+// UUIDs are assigned to the component and vulnerability of f if they do not have one.
+func (s *Server) AddFinding(projectUUID uuid.UUID, f dtrack.Finding) dtrack.Finding {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if f.Component.UUID == uuid.Nil {
+		f.Component.UUID = uuid.New()
+	}
+	if f.Vulnerability.UUID == uuid.Nil {
+		f.Vulnerability.UUID = uuid.New()
+	}
+	f.Component.Project = projectUUID
+	s.findings[projectUUID] = append(s.findings[projectUUID], f)
+
+	return f
+}
+
+// AddPolicyViolation seeds a policy violation of a project.
+// UUIDs are assigned to v and its component if they do not have one.
+func (s *Server) AddPolicyViolation(projectUUID uuid.UUID, v dtrack.PolicyViolation) dtrack.PolicyViolation {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if v.UUID == uuid.Nil {
+		v.UUID = uuid.New()
+	}
+	if v.Component.UUID == uuid.Nil {
+		v.Component.UUID = uuid.New()
+	}
+	v.Project = s.projects[projectUUID]
+	s.violations[projectUUID] = append(s.violations[projectUUID], v)
+
+	return v
+}
+
+// AddPolicy seeds a policy. A UUID is assigned if p does not have one.
+func (s *Server) AddPolicy(p dtrack.Policy) dtrack.Policy {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if p.UUID == uuid.Nil {
+		p.UUID = uuid.New()
+	}
+	s.policies[p.UUID] = p
+
+	return p
+}
+
+// AddTeam seeds a team. A UUID is assigned if t does not have one.
+func (s *Server) AddTeam(t dtrack.Team) dtrack.Team {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if t.UUID == uuid.Nil {
+		t.UUID = uuid.New()
+	}
+	s.teams[t.UUID] = t
+
+	return t
+}
+
+// Projects returns all projects known to the server, sorted by name and version.
+func (s *Server) Projects() []dtrack.Project {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.sortedProjects()
+}
+
+// BOMUploads returns all BOMs uploaded to the server, in the order they were uploaded.
+func (s *Server) BOMUploads() []BOMUpload {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]BOMUpload(nil), s.bomUploads...)
+}
+
+// MetricsRefreshes returns the UUIDs of the projects whose metrics were refreshed,
+// in the order the refreshes were requested.
+func (s *Server) MetricsRefreshes() []uuid.UUID {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]uuid.UUID(nil), s.refreshes...)
+}
+
+func (s *Server) sortedProjects() []dtrack.Project {
+	projects := make([]dtrack.Project, 0, len(s.projects))
+	for _, p := range s.projects {
+		projects = append(projects, p)
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		if projects[i].Name != projects[j].Name {
+			return projects[i].Name < projects[j].Name
+		}
+		return projects[i].Version < projects[j].Version
+	})
+
+	return projects
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/version", s.handleVersion)
+
+	mux.HandleFunc("GET /api/v1/project", s.handleGetProjects)
+	mux.HandleFunc("PUT /api/v1/project", s.handleCreateProject)
+	mux.HandleFunc("POST /api/v1/project", s.handleUpdateProject)
+	mux.HandleFunc("GET /api/v1/project/lookup", s.handleLookupProject)
+	mux.HandleFunc("PUT /api/v1/project/clone", s.handleCloneProject)
+	mux.HandleFunc("GET /api/v1/project/tag/{tag}", s.handleGetProjectsByTag)
+	mux.HandleFunc("GET /api/v1/project/classifier/{classifier}", s.handleGetProjectsByClassifier)
+	mux.HandleFunc("GET /api/v1/project/{uuid}", s.handleGetProject)
+	// A wildcard is used for the last segment, because "{uuid}/children" would conflict with "tag/{tag}".
+	mux.HandleFunc("GET /api/v1/project/{uuid}/{relation}", s.handleGetProjectRelation)
+	mux.HandleFunc("PATCH /api/v1/project/{uuid}", s.handlePatchProject)
+	mux.HandleFunc("DELETE /api/v1/project/{uuid}", s.handleDeleteProject)
+	mux.HandleFunc("PUT /api/v1/project/{uuid}/property", s.handleCreateProjectProperty)
+	mux.HandleFunc("POST /api/v1/project/{uuid}/property", s.handleUpdateProjectProperty)
+	mux.HandleFunc("DELETE /api/v1/project/{uuid}/property", s.handleDeleteProjectProperty)
+
+	mux.HandleFunc("GET /api/v1/component/{uuid}", s.handleGetComponent)
+	mux.HandleFunc("POST /api/v1/component", s.handleUpdateComponent)
+	mux.HandleFunc("GET /api/v1/component/project/{uuid}", s.handleGetComponents)
+	mux.HandleFunc("PUT /api/v1/component/project/{uuid}", s.handleCreateComponent)
+
+	mux.HandleFunc("GET /api/v1/finding", s.handleGetPortfolioFindings)
+	mux.HandleFunc("GET /api/v1/finding/grouped", s.handleGetGroupedFindings)
+	mux.HandleFunc("GET /api/v1/finding/project/{uuid}", s.handleGetFindings)
+	mux.HandleFunc("POST /api/v1/finding/project/{uuid}/analyze", s.handleAnalyzeProject)
+
+	mux.HandleFunc("GET /api/v1/violation/project/{uuid}", s.handleGetProjectViolations)
+
+	mux.HandleFunc("GET /api/v1/analysis", s.handleGetAnalysis)
+	mux.HandleFunc("PUT /api/v1/analysis", s.handleUpdateAnalysis)
+
+	mux.HandleFunc("GET /api/v1/policy", s.handleGetPolicies)
+	mux.HandleFunc("PUT /api/v1/policy", s.handleCreatePolicy)
+	mux.HandleFunc("POST /api/v1/policy", s.handleUpdatePolicy)
+	mux.HandleFunc("GET /api/v1/policy/{uuid}", s.handleGetPolicy)
+	mux.HandleFunc("DELETE /api/v1/policy/{uuid}", s.handleDeletePolicy)
+
+	mux.HandleFunc("GET /api/v1/team", s.handleGetTeams)
+	mux.HandleFunc("PUT /api/v1/team", s.handleCreateTeam)
+	mux.HandleFunc("POST /api/v1/team", s.handleUpdateTeam)
+	mux.HandleFunc("DELETE /api/v1/team", s.handleDeleteTeam)
+	mux.HandleFunc("GET /api/v1/team/{uuid}", s.handleGetTeam)
+
+	mux.HandleFunc("GET /api/v1/acl/team/{uuid}", s.handleGetACLProjects)
+	mux.HandleFunc("PUT /api/v1/acl/mapping", s.handleCreateACLMapping)
+	mux.HandleFunc("DELETE /api/v1/acl/mapping/team/{team}/project/{project}", s.handleDeleteACLMapping)
+
+	mux.HandleFunc("PUT /api/v1/bom", s.handleUploadBOM)
+	mux.HandleFunc("POST /api/v1/bom", s.handlePostBOM)
+	mux.HandleFunc("GET /api/v1/bom/token/{token}", s.handleGetToken)
+
+	mux.HandleFunc("PUT /api/v1/vex", s.handleUploadVEX)
+
+	mux.HandleFunc("GET /api/v1/event/token/{token}", s.handleGetToken)
+
+	mux.HandleFunc("GET /api/v1/metrics/portfolio/refresh", s.handleRefreshPortfolioMetrics)
+	mux.HandleFunc("GET /api/v1/metrics/project/{uuid}/refresh", s.handleRefreshProjectMetrics)
+
+	return s.middleware(mux)
+}
+
+// middleware injects failures and performs authentication.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failure := s.matchFailure(r); failure != nil {
+			w.WriteHeader(failure.StatusCode)
+			_, _ = w.Write([]byte(failure.Body))
+			return
+		}
+
+		if r.URL.Path != "/api/version" && !s.isAuthenticated(r) {
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) matchFailure(r *http.Request) *Failure {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i, f := range s.failures {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.PathPrefix) {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+		}
+
+		return f
+	}
+
+	return nil
+}
+
+func (s *Server) isAuthenticated(r *http.Request) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.apiKeys) == 0 {
+		return true
+	}
+
+	apiKey := r.Header.Get("X-Api-Key")
+	if apiKey == "" {
+		apiKey = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+
+	_, ok := s.apiKeys[apiKey]
+	return ok
+}
+
+// paginate returns the requested page of items, and sets the X-Total-Count header.
+// Like Dependency-Track, all items are returned if no pagination parameters are provided.
+func paginate[T any](w http.ResponseWriter, r *http.Request, items []T) []T {
+	w.Header().Set("X-Total-Count", strconv.Itoa(len(items)))
+
+	query := r.URL.Query()
+	pageSize, _ := strconv.Atoi(query.Get("pageSize"))
+	if pageSize <= 0 {
+		return items
+	}
+
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	if offset <= 0 {
+		if pageNumber, _ := strconv.Atoi(query.Get("pageNumber")); pageNumber > 1 {
+			offset = (pageNumber - 1) * pageSize
+		}
+	}
+
+	if offset >= len(items) {
+		return []T{}
+	}
+
+	end := offset + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[offset:end]
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write([]byte(message))
+}
+
+func pathUUID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(r.PathValue("uuid"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid UUID")
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+// sortItems sorts items by the field named by the sortName query parameter, in the order given by sortOrder,
+// using the comparison functions of fields. It responds with 501 Not Implemented if the field is not one of fields,
+// and reports whether items were sorted.
+func sortItems[T any](w http.ResponseWriter, r *http.Request, items []T, fields map[string]func(a, b T) int) bool {
+	query := r.URL.Query()
+	name := query.Get("sortName")
+	if name == "" {
+		return true
+	}
+
+	compare, ok := fields[name]
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "Sorting by "+name+" is not supported by dtracktest")
+		return false
+	}
+
+	descending := query.Get("sortOrder") == string(dtrack.SortDescending)
+	sort.SliceStable(items, func(i, j int) bool {
+		if descending {
+			return compare(items[j], items[i]) < 0
+		}
+		return compare(items[i], items[j]) < 0
+	})
+
+	return true
+}
+
+// rejectParams responds with 501 Not Implemented if r has one of the query parameters params,
+// and reports whether it did.
+func rejectParams(w http.ResponseWriter, r *http.Request, params ...string) bool {
+	query := r.URL.Query()
+	for _, param := range params {
+		if query.Has(param) {
+			writeError(w, http.StatusNotImplemented, "The "+param+" parameter is not supported by dtracktest")
+			return true
+		}
+	}
+
+	return false
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,421 @@
+package dtracktest
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+)
+
+func TestServer_Version(t *testing.T) {
+	server := NewServer(WithVersion("4.8.2"))
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	about, err := client.About.Get(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, "4.8.2", about.Version)
+}
+
+func TestServer_Authentication(t *testing.T) {
+	server := NewServer(WithAPIKey("secret"))
+	defer server.Close()
+
+	client, err := dtrack.NewClient(server.URL(), dtrack.WithAPIKey("wrong"))
+	require.NoError(t, err)
+
+	_, err = client.Project.GetAll(context.TODO(), dtrack.PageOptions{})
+	require.ErrorIs(t, err, dtrack.ErrUnauthorized)
+
+	client, err = server.Client()
+	require.NoError(t, err)
+
+	_, err = client.Project.GetAll(context.TODO(), dtrack.PageOptions{})
+	require.NoError(t, err)
+}
+
+func TestServer_Projects(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	for i := 0; i < 7; i++ {
+		server.AddProject(dtrack.Project{Name: fmt.Sprintf("project-%d", i), Version: "1.0.0", Active: true})
+	}
+
+	t.Run("Pagination", func(t *testing.T) {
+		page, err := client.Project.GetAll(context.TODO(), dtrack.PageOptions{PageNumber: 2, PageSize: 3})
+		require.NoError(t, err)
+		require.Equal(t, 7, page.TotalCount)
+		require.Len(t, page.Items, 3)
+		require.Equal(t, "project-3", page.Items[0].Name)
+
+		var names []string
+		for project, err := range client.Project.All(context.TODO(), dtrack.WithPageSize(2)) {
+			require.NoError(t, err)
+			names = append(names, project.Name)
+		}
+		require.Len(t, names, 7)
+	})
+
+	t.Run("CreateAndLookup", func(t *testing.T) {
+		created, err := client.Project.Create(context.TODO(), dtrack.Project{Name: "acme-app", Version: "2.0.0"})
+		require.NoError(t, err)
+		require.NotEqual(t, uuid.Nil, created.UUID)
+
+		_, err = client.Project.Create(context.TODO(), dtrack.Project{Name: "acme-app", Version: "2.0.0"})
+		require.ErrorIs(t, err, dtrack.ErrConflict)
+
+		found, err := client.Project.Lookup(context.TODO(), "acme-app", "2.0.0")
+		require.NoError(t, err)
+		require.Equal(t, created.UUID, found.UUID)
+
+		_, err = client.Project.Lookup(context.TODO(), "acme-app", "3.0.0")
+		require.ErrorIs(t, err, dtrack.ErrNotFound)
+	})
+
+	t.Run("PatchAndDelete", func(t *testing.T) {
+		project := server.AddProject(dtrack.Project{Name: "patch-me", Version: "1.0.0", Description: "before"})
+
+		patched, err := client.Project.Patch(context.TODO(), project.UUID, dtrack.Project{Description: "after"})
+		require.NoError(t, err)
+		require.Equal(t, "patch-me", patched.Name)
+		require.Equal(t, "after", patched.Description)
+
+		require.NoError(t, client.Project.Delete(context.TODO(), project.UUID))
+
+		_, err = client.Project.Get(context.TODO(), project.UUID)
+		require.ErrorIs(t, err, dtrack.ErrNotFound)
+	})
+
+	t.Run("Classifier", func(t *testing.T) {
+		library := server.AddProject(dtrack.Project{Name: "acme-lib", Version: "1.0.0", Classifier: "LIBRARY", Active: true})
+		server.AddProject(dtrack.Project{Name: "acme-lib", Version: "0.9.0", Classifier: "LIBRARY", Active: false})
+
+		page, err := client.Project.GetAllFiltered(context.TODO(), dtrack.PageOptions{}, dtrack.ProjectListOptions{Classifier: "LIBRARY", ExcludeInactive: true})
+		require.NoError(t, err)
+		require.Equal(t, 1, page.TotalCount)
+		require.Equal(t, library.UUID, page.Items[0].UUID)
+	})
+
+	t.Run("Filtered", func(t *testing.T) {
+		team := server.AddTeam(dtrack.Team{Name: "acme-team"})
+		assigned := server.AddProject(dtrack.Project{Name: "project-assigned", Version: "1.0.0", Active: true})
+		require.NoError(t, client.ACLMapping.Create(context.TODO(), dtrack.ACLMapping{Team: team.UUID, Project: assigned.UUID}))
+
+		var names []string
+		for project, err := range client.Project.AllFiltered(context.TODO(), dtrack.ProjectListOptions{
+			SearchText:                "PROJECT-",
+			NotAssignedToTeamWithUUID: team.UUID,
+			Sort:                      dtrack.SortOptions{Name: "name", Order: dtrack.SortDescending},
+		}, dtrack.WithPageSize(3)) {
+			require.NoError(t, err)
+			names = append(names, project.Name)
+		}
+		require.Equal(t, []string{"project-6", "project-5", "project-4", "project-3", "project-2", "project-1", "project-0"}, names)
+
+		_, err := client.Project.GetAllFiltered(context.TODO(), dtrack.PageOptions{}, dtrack.ProjectListOptions{Sort: dtrack.SortOptions{Name: "purl"}})
+		var apiErr *dtrack.APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotImplemented, apiErr.StatusCode)
+	})
+}
+
+func TestServer_FilteredComponentsAndFindings(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	project := server.AddProject(dtrack.Project{Name: "acme-app", Version: "1.0.0"})
+	direct := server.AddComponent(project.UUID, dtrack.Component{Name: "log4j-core", Version: "2.14.1"})
+	server.AddComponent(project.UUID, dtrack.Component{Name: "log4j-api", Version: "2.14.1"})
+	server.AddComponent(project.UUID, dtrack.Component{Name: "jackson-databind", Version: "2.13.0"})
+
+	project.DirectDependencies = `[{"uuid":"` + direct.UUID.String() + `"}]`
+	server.AddProject(project)
+
+	components, err := client.Component.GetAllFiltered(context.TODO(), project.UUID, dtrack.PageOptions{},
+		dtrack.ComponentListOptions{SearchText: "log4j", Sort: dtrack.SortOptions{Name: "name", Order: dtrack.SortDescending}})
+	require.NoError(t, err)
+	require.Equal(t, 2, components.TotalCount)
+	require.Equal(t, "log4j-core", components.Items[0].Name)
+	require.Equal(t, "log4j-api", components.Items[1].Name)
+
+	components, err = client.Component.GetAllFiltered(context.TODO(), project.UUID, dtrack.PageOptions{}, dtrack.ComponentListOptions{OnlyDirect: true})
+	require.NoError(t, err)
+	require.Len(t, components.Items, 1)
+	require.Equal(t, direct.UUID, components.Items[0].UUID)
+
+	_, err = client.Component.GetAllFiltered(context.TODO(), project.UUID, dtrack.PageOptions{}, dtrack.ComponentListOptions{OnlyOutdated: true})
+	var apiErr *dtrack.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusNotImplemented, apiErr.StatusCode)
+
+	server.AddFinding(project.UUID, dtrack.Finding{
+		Component:     dtrack.FindingComponent{Name: "log4j-core"},
+		Vulnerability: dtrack.FindingVulnerability{VulnID: "CVE-2021-45046", Source: "NVD", Severity: "CRITICAL"},
+	})
+	server.AddFinding(project.UUID, dtrack.Finding{
+		Component:     dtrack.FindingComponent{Name: "log4j-core"},
+		Vulnerability: dtrack.FindingVulnerability{VulnID: "CVE-2021-45105", Source: "NVD", Severity: "MEDIUM"},
+	})
+	server.AddFinding(project.UUID, dtrack.Finding{
+		Component:     dtrack.FindingComponent{Name: "jackson-databind"},
+		Vulnerability: dtrack.FindingVulnerability{VulnID: "GHSA-57j2-w4cx-62h2", Source: "GITHUB", Severity: "HIGH"},
+	})
+
+	findings, err := client.Finding.GetAllFiltered(context.TODO(), project.UUID, false, dtrack.PageOptions{},
+		dtrack.FindingListOptions{Source: "NVD", Sort: dtrack.SortOptions{Name: "vulnerability.severity", Order: dtrack.SortDescending}})
+	require.NoError(t, err)
+	require.Equal(t, 2, findings.TotalCount)
+	require.Equal(t, "CVE-2021-45046", findings.Items[0].Vulnerability.VulnID)
+	require.Equal(t, "CVE-2021-45105", findings.Items[1].Vulnerability.VulnID)
+
+	findings, err = client.Finding.GetAllFiltered(context.TODO(), project.UUID, false, dtrack.PageOptions{}, dtrack.FindingListOptions{SearchText: "jackson"})
+	require.NoError(t, err)
+	require.Len(t, findings.Items, 1)
+	require.Equal(t, "GHSA-57j2-w4cx-62h2", findings.Items[0].Vulnerability.VulnID)
+}
+
+func TestServer_InjectFailure(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	server.InjectFailure(Failure{
+		Method:     http.MethodGet,
+		PathPrefix: "/api/v1/project",
+		StatusCode: http.StatusTooManyRequests,
+		Times:      1,
+	})
+
+	_, err = client.Project.GetAll(context.TODO(), dtrack.PageOptions{})
+	require.ErrorIs(t, err, dtrack.ErrRateLimited)
+
+	_, err = client.Project.GetAll(context.TODO(), dtrack.PageOptions{})
+	require.NoError(t, err)
+}
+
+func TestServer_BOMUpload(t *testing.T) {
+	server := NewServer(WithProcessingPolls(2))
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	t.Run("AutoCreate", func(t *testing.T) {
+		token, err := client.BOM.Upload(context.TODO(), dtrack.BOMUploadRequest{
+			ProjectName:    "acme-app",
+			ProjectVersion: "1.0.0",
+			AutoCreate:     true,
+			BOM:            base64.StdEncoding.EncodeToString([]byte("<bom/>")),
+		})
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			processing, err := client.BOM.IsBeingProcessed(context.TODO(), token)
+			require.NoError(t, err)
+			require.True(t, processing)
+		}
+
+		processing, err := client.BOM.IsBeingProcessed(context.TODO(), token)
+		require.NoError(t, err)
+		require.False(t, processing)
+
+		projects := server.Projects()
+		require.Len(t, projects, 1)
+
+		uploads := server.BOMUploads()
+		require.Len(t, uploads, 1)
+		require.Equal(t, string(token), uploads[0].Token)
+		require.Equal(t, projects[0].UUID, uploads[0].ProjectUUID)
+		require.Equal(t, "<bom/>", string(uploads[0].BOM))
+	})
+
+	t.Run("Multipart", func(t *testing.T) {
+		_, err := client.BOM.PostBom(context.TODO(), dtrack.BOMUploadRequest{
+			ProjectName:    "acme-app",
+			ProjectVersion: "1.0.0",
+			BOM:            "<bom/>",
+		})
+		require.NoError(t, err)
+		require.Len(t, server.BOMUploads(), 2)
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		_, err := client.BOM.UploadReader(context.TODO(), dtrack.BOMUploadMetadata{
+			ProjectName:    "acme-app",
+			ProjectVersion: "1.0.0",
+		}, strings.NewReader("<bom/>"), dtrack.WithGzip())
+		require.NoError(t, err)
+
+		uploads := server.BOMUploads()
+		require.Len(t, uploads, 3)
+		require.Equal(t, "<bom/>", string(uploads[2].BOM))
+	})
+
+	t.Run("UploadAndWait", func(t *testing.T) {
+		project, err := client.BOM.UploadAndWait(context.TODO(), dtrack.BOMUploadRequest{
+			ProjectName:    "acme-app",
+			ProjectVersion: "1.0.0",
+			BOM:            base64.StdEncoding.EncodeToString([]byte("<bom/>")),
+		}, dtrack.UploadAndWaitOptions{
+			Wait:           dtrack.WaitOptions{PollInterval: time.Millisecond},
+			RefreshMetrics: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{project.UUID}, server.MetricsRefreshes())
+	})
+
+	t.Run("UnknownProject", func(t *testing.T) {
+		_, err := client.BOM.Upload(context.TODO(), dtrack.BOMUploadRequest{
+			ProjectName:    "unknown",
+			ProjectVersion: "1.0.0",
+			BOM:            base64.StdEncoding.EncodeToString([]byte("<bom/>")),
+		})
+		require.ErrorIs(t, err, dtrack.ErrNotFound)
+	})
+}
+
+func TestServer_Analysis(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	project := server.AddProject(dtrack.Project{Name: "acme-app", Version: "1.0.0"})
+	finding := server.AddFinding(project.UUID, dtrack.Finding{
+		Vulnerability: dtrack.FindingVulnerability{VulnID: "CVE-2021-44228"},
+	})
+
+	findings, err := client.Finding.GetAll(context.TODO(), project.UUID, false, dtrack.PageOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 1, findings.TotalCount)
+
+	suppressed := true
+	analysis, err := client.Analysis.Create(context.TODO(), dtrack.AnalysisRequest{
+		Component:     finding.Component.UUID,
+		Project:       project.UUID,
+		Vulnerability: finding.Vulnerability.UUID,
+		State:         dtrack.AnalysisStateFalsePositive,
+		Suppressed:    &suppressed,
+	})
+	require.NoError(t, err)
+	require.Equal(t, dtrack.AnalysisStateFalsePositive, analysis.State)
+
+	findings, err = client.Finding.GetAll(context.TODO(), project.UUID, false, dtrack.PageOptions{})
+	require.NoError(t, err)
+	require.Empty(t, findings.Items)
+
+	findings, err = client.Finding.GetAll(context.TODO(), project.UUID, true, dtrack.PageOptions{})
+	require.NoError(t, err)
+	require.Len(t, findings.Items, 1)
+	require.True(t, findings.Items[0].Analysis.Suppressed)
+}
+
+func TestServer_EventTokens(t *testing.T) {
+	server := NewServer(WithProcessingPolls(1))
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	project := server.AddProject(dtrack.Project{Name: "acme-app", Version: "1.0.0"})
+
+	token, err := client.Finding.AnalyzeProject(context.TODO(), project.UUID)
+	require.NoError(t, err)
+
+	processing, err := client.Event.IsBeingProcessed(context.TODO(), token)
+	require.NoError(t, err)
+	require.True(t, processing)
+
+	processing, err = client.Event.IsBeingProcessed(context.TODO(), token)
+	require.NoError(t, err)
+	require.False(t, processing)
+
+	token, err = client.VEX.Upload(context.TODO(), dtrack.VEXUploadRequest{
+		ProjectUUID: &project.UUID,
+		VEX:         base64.StdEncoding.EncodeToString([]byte("{}")),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+}
+
+func TestServer_PortfolioFindings(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	app := server.AddProject(dtrack.Project{Name: "acme-app", Version: "1.0.0", Active: true})
+	lib := server.AddProject(dtrack.Project{Name: "acme-lib", Version: "1.0.0", Active: true})
+	inactive := server.AddProject(dtrack.Project{Name: "acme-old", Version: "0.1.0", Active: false})
+
+	log4Shell := dtrack.Finding{
+		Component:     dtrack.FindingComponent{Name: "log4j-core", Version: "2.14.1"},
+		Vulnerability: dtrack.FindingVulnerability{VulnID: "CVE-2021-44228", Source: "NVD", Severity: "CRITICAL", CVSSV3BaseScore: 10},
+	}
+	server.AddFinding(app.UUID, log4Shell)
+	server.AddFinding(lib.UUID, log4Shell)
+	server.AddFinding(inactive.UUID, log4Shell)
+	server.AddFinding(lib.UUID, dtrack.Finding{
+		Component:     dtrack.FindingComponent{Name: "log4j-core", Version: "2.14.1"},
+		Vulnerability: dtrack.FindingVulnerability{VulnID: "CVE-2021-45105", Source: "NVD", Severity: "MEDIUM", CVSSV3BaseScore: 5.9},
+	})
+	server.AddFinding(app.UUID, dtrack.Finding{
+		Analysis:      dtrack.FindingAnalysis{State: string(dtrack.AnalysisStateFalsePositive), Suppressed: true},
+		Component:     dtrack.FindingComponent{Name: "jackson-databind", Version: "2.13.0"},
+		Vulnerability: dtrack.FindingVulnerability{VulnID: "GHSA-57j2-w4cx-62h2", Source: "GITHUB", Severity: "HIGH"},
+	})
+
+	page, err := client.Finding.GetAllPortfolio(context.TODO(), dtrack.PageOptions{},
+		dtrack.PortfolioFindingListOptions{Severities: []dtrack.Severity{dtrack.SeverityCritical, dtrack.SeverityHigh}})
+	require.NoError(t, err)
+	require.Equal(t, 2, page.TotalCount)
+	require.Equal(t, "acme-app", page.Items[0].Component.ProjectName)
+	require.Equal(t, "acme-lib", page.Items[1].Component.ProjectName)
+
+	var vulnIDs []string
+	for finding, err := range client.Finding.AllPortfolio(context.TODO(), dtrack.PortfolioFindingListOptions{
+		ShowInactive:   true,
+		ShowSuppressed: true,
+		CVSSv3:         dtrack.ScoreRange{Max: 6},
+	}) {
+		require.NoError(t, err)
+		vulnIDs = append(vulnIDs, finding.Vulnerability.VulnID)
+	}
+	require.Equal(t, []string{"GHSA-57j2-w4cx-62h2", "CVE-2021-45105"}, vulnIDs)
+
+	grouped, err := client.Finding.GetGrouped(context.TODO(), dtrack.PageOptions{}, dtrack.PortfolioFindingListOptions{
+		Sort: dtrack.SortOptions{Name: "vulnerability.severity", Order: dtrack.SortDescending},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, grouped.TotalCount)
+	require.Equal(t, "CVE-2021-44228", grouped.Items[0].Vulnerability.VulnID)
+	require.Equal(t, 2, grouped.Items[0].Vulnerability.AffectedProjectCount)
+	require.Equal(t, "CVE-2021-45105", grouped.Items[1].Vulnerability.VulnID)
+	require.Equal(t, 1, grouped.Items[1].Vulnerability.AffectedProjectCount)
+
+	_, err = client.Finding.GetAllPortfolio(context.TODO(), dtrack.PageOptions{}, dtrack.PortfolioFindingListOptions{SearchText: "log4j"})
+	var apiErr *dtrack.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusNotImplemented, apiErr.StatusCode)
+}
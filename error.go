@@ -1,21 +1,86 @@
 package dtrack
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 )
 
+// Sentinel errors that APIErrors can be matched against using errors.Is.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// maxErrorBodySize is the maximum number of bytes of an error response body retained in an APIError.
+const maxErrorBodySize = 4 << 10
+
+// requestIDHeaders are the headers that are checked for a request ID, in order of precedence.
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Amzn-Trace-Id"}
+
+// APIError is returned when the API responds with a non-2xx status code.
 type APIError struct {
 	StatusCode int
-	Message    string
+	Message    string          // Response body, truncated to a safe size
+	Truncated  bool            // Whether Message has been truncated
+	Method     string          // HTTP method of the failed request
+	URL        string          // URL of the failed request
+	RequestID  string          // Request ID as reported by the server or a proxy in front of it
+	Problem    *ProblemDetails // Problem details, if the server responded with any
+}
+
+// ProblemDetails represents an RFC 9457 problem details object.
+// Dependency-Track responds with problem details since v4.11.0.
+type ProblemDetails struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
 }
 
 func (e APIError) Error() string {
-	if e.Message == "" {
-		return fmt.Sprintf("api error (status: %d)", e.StatusCode)
+	message := e.Message
+	if e.Problem != nil {
+		if e.Problem.Detail != "" {
+			message = e.Problem.Detail
+		} else if e.Problem.Title != "" {
+			message = e.Problem.Title
+		}
+	}
+
+	if message == "" {
+		message = "api error"
+	}
+	if e.Method != "" && e.URL != "" {
+		message = fmt.Sprintf("%s %s: %s", e.Method, e.URL, message)
+	}
+
+	return fmt.Sprintf("%s (status: %d)", message, e.StatusCode)
+}
+
+// Is reports whether the APIError matches one of the sentinel errors.
+func (e APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	default:
+		return false
 	}
-	return fmt.Sprintf("%s (status: %d)", e.Message, e.StatusCode)
 }
 
 func checkResponseForError(res *http.Response) error {
@@ -25,10 +90,44 @@ func checkResponseForError(res *http.Response) error {
 
 	apiErr := &APIError{StatusCode: res.StatusCode}
 
-	body, err := io.ReadAll(res.Body)
+	if res.Request != nil {
+		apiErr.Method = res.Request.Method
+		if res.Request.URL != nil {
+			apiErr.URL = res.Request.URL.Redacted()
+		}
+	}
+
+	for _, header := range requestIDHeaders {
+		if requestID := res.Header.Get(header); requestID != "" {
+			apiErr.RequestID = requestID
+			break
+		}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize+1))
 	if err == nil && body != nil {
+		if len(body) > maxErrorBodySize {
+			body = body[:maxErrorBodySize]
+			apiErr.Truncated = true
+		}
 		apiErr.Message = string(body)
+
+		if !apiErr.Truncated && isJSONContentType(res.Header.Get("Content-Type")) {
+			var problem ProblemDetails
+			if json.Unmarshal(body, &problem) == nil && (problem.Title != "" || problem.Detail != "") {
+				apiErr.Problem = &problem
+			}
+		}
 	}
 
 	return apiErr
 }
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || mediaType == "application/problem+json"
+}
@@ -0,0 +1,77 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckResponseForError(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	projectUUID := uuid.MustParse("6fb1820f-5280-4577-ac51-40124aabe307")
+
+	t.Run("SentinelErrors", func(t *testing.T) {
+		for statusCode, sentinel := range map[int]error{
+			http.StatusNotFound:        ErrNotFound,
+			http.StatusUnauthorized:    ErrUnauthorized,
+			http.StatusForbidden:       ErrForbidden,
+			http.StatusConflict:        ErrConflict,
+			http.StatusTooManyRequests: ErrRateLimited,
+		} {
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/"+projectUUID.String(),
+				httpmock.NewStringResponder(statusCode, ""))
+
+			_, err := client.Project.Get(context.TODO(), projectUUID)
+			require.ErrorIs(t, err, sentinel)
+
+			var apiErr *APIError
+			require.True(t, errors.As(err, &apiErr))
+			require.Equal(t, statusCode, apiErr.StatusCode)
+			require.Equal(t, http.MethodGet, apiErr.Method)
+			require.Equal(t, "http://localhost/api/v1/project/"+projectUUID.String(), apiErr.URL)
+		}
+	})
+
+	t.Run("ProblemDetails", func(t *testing.T) {
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/"+projectUUID.String(),
+			httpmock.NewStringResponder(http.StatusNotFound, `{"status":404,"title":"Not Found","detail":"The project could not be found."}`).
+				HeaderSet(http.Header{
+					"Content-Type": []string{"application/problem+json"},
+					"X-Request-Id": []string{"abc123"},
+				}))
+
+		_, err := client.Project.Get(context.TODO(), projectUUID)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.NotErrorIs(t, err, ErrForbidden)
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, "abc123", apiErr.RequestID)
+		require.NotNil(t, apiErr.Problem)
+		require.Equal(t, "Not Found", apiErr.Problem.Title)
+		require.Contains(t, apiErr.Error(), "The project could not be found.")
+	})
+
+	t.Run("TruncatesBody", func(t *testing.T) {
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/"+projectUUID.String(),
+			httpmock.NewStringResponder(http.StatusInternalServerError, strings.Repeat("x", 3*maxErrorBodySize)))
+
+		_, err := client.Project.Get(context.TODO(), projectUUID)
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.True(t, apiErr.Truncated)
+		require.Len(t, apiErr.Message, maxErrorBodySize)
+	})
+}
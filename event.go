@@ -0,0 +1,57 @@
+package dtrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// EventToken identifies an asynchronous task in Dependency-Track,
+// e.g. the processing of a BOM or VEX upload, or the analysis of a project.
+type EventToken string
+
+type eventTokenResponse struct {
+	Token EventToken `json:"token"`
+}
+
+type eventProcessingResponse struct {
+	Processing bool `json:"processing"`
+}
+
+type EventService struct {
+	client *Client
+}
+
+// IsBeingProcessed reports whether the task identified by token is still being processed.
+// Unknown tokens are reported as not being processed.
+// This feature is available in Dependency-Track v4.11.0 and newer.
+func (es EventService) IsBeingProcessed(ctx context.Context, token EventToken) (processing bool, err error) {
+	if err = es.client.requireFeature(ctx, FeatureEventTokens); err != nil {
+		return
+	}
+
+	req, err := es.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/event/token/%s", token))
+	if err != nil {
+		return
+	}
+
+	var processingRes eventProcessingResponse
+	_, err = es.client.doRequest(req, &processingRes)
+	if err != nil {
+		return
+	}
+
+	processing = processingRes.Processing
+	return
+}
+
+// WaitForProcessing waits until the task identified by token completed,
+// by polling IsBeingProcessed. A *ProcessingTimeoutError is returned when opts.Timeout is exceeded.
+func (es EventService) WaitForProcessing(ctx context.Context, token EventToken, opts WaitOptions) (err error) {
+	ctx, endSpan := es.client.startSpan(ctx, "Event.WaitForProcessing")
+	defer func() { endSpan(err) }()
+
+	return waitForProcessing(ctx, string(token), opts, func(ctx context.Context) (bool, error) {
+		return es.IsBeingProcessed(ctx, token)
+	})
+}
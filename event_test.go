@@ -0,0 +1,63 @@
+package dtrack
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventService_IsBeingProcessed(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/event/token/foo",
+		httpmock.NewStringResponder(http.StatusOK, `{"processing":true}`))
+
+	processing, err := client.Event.IsBeingProcessed(context.TODO(), "foo")
+	require.NoError(t, err)
+	require.True(t, processing)
+}
+
+func TestEventService_IsBeingProcessed_Unsupported(t *testing.T) {
+	client, err := NewClient("http://localhost", WithServerVersionCheck())
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+		httpmock.NewStringResponder(http.StatusOK, `{"version":"4.10.1"}`))
+
+	_, err = client.Event.IsBeingProcessed(context.TODO(), "foo")
+	require.ErrorIs(t, err, ErrUnsupportedByServer)
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestEventService_WaitForProcessing(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/vex",
+		httpmock.NewStringResponder(http.StatusOK, `{"token":"foo"}`))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/event/token/foo",
+		httpmock.NewStringResponder(http.StatusOK, `{"processing":true}`).
+			Then(httpmock.NewStringResponder(http.StatusOK, `{"processing":false}`)))
+
+	token, err := client.VEX.Upload(context.TODO(), VEXUploadRequest{ProjectName: "acme-app", VEX: "dmV4"})
+	require.NoError(t, err)
+	require.Equal(t, EventToken("foo"), token)
+
+	err = client.Event.WaitForProcessing(context.TODO(), token, WaitOptions{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	require.Equal(t, 2, httpmock.GetCallCountInfo()["GET http://localhost/api/v1/event/token/foo"])
+}
@@ -3,6 +3,7 @@ package dtrack
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strconv"
 
@@ -31,14 +32,16 @@ type FindingAttribution struct {
 }
 
 type FindingComponent struct {
-	UUID          uuid.UUID `json:"uuid"`
-	Group         string    `json:"group"`
-	Name          string    `json:"name"`
-	Version       string    `json:"version"`
-	CPE           string    `json:"cpe"`
-	PURL          string    `json:"purl"`
-	LatestVersion string    `json:"latestVersion"`
-	Project       uuid.UUID `json:"project"`
+	UUID           uuid.UUID `json:"uuid"`
+	Group          string    `json:"group"`
+	Name           string    `json:"name"`
+	Version        string    `json:"version"`
+	CPE            string    `json:"cpe"`
+	PURL           string    `json:"purl"`
+	LatestVersion  string    `json:"latestVersion"`
+	Project        uuid.UUID `json:"project"`
+	ProjectName    string    `json:"projectName,omitempty"`    // Only populated for portfolio findings
+	ProjectVersion string    `json:"projectVersion,omitempty"` // Only populated for portfolio findings
 }
 
 type FindingVulnerability struct {
@@ -60,19 +63,78 @@ type FindingVulnerability struct {
 	EPSSScore                   float64              `json:"epssScore"`
 	EPSSPercentile              float64              `json:"epssPercentile"`
 	CWEs                        []CWE                `json:"cwes"`
+	AffectedProjectCount        int                  `json:"affectedProjectCount,omitempty"` // Only populated for grouped findings
+}
+
+type Severity string
+
+const (
+	SeverityCritical   Severity = "CRITICAL"
+	SeverityHigh       Severity = "HIGH"
+	SeverityMedium     Severity = "MEDIUM"
+	SeverityLow        Severity = "LOW"
+	SeverityInfo       Severity = "INFO"
+	SeverityUnassigned Severity = "UNASSIGNED"
+)
+
+// Rank returns the rank Dependency-Track assigns to the severity, as reported in FindingVulnerability.SeverityRank.
+// More severe severities have lower ranks. Unknown severities rank below SeverityUnassigned.
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityCritical:
+		return 0
+	case SeverityHigh:
+		return 1
+	case SeverityMedium:
+		return 2
+	case SeverityLow:
+		return 3
+	case SeverityInfo:
+		return 4
+	case SeverityUnassigned:
+		return 5
+	}
+
+	return 6
 }
 
 type FindingService struct {
 	client *Client
 }
 
+// FindingListOptions filters and sorts the findings returned by FindingService.GetAllFiltered.
+type FindingListOptions struct {
+	Source     string // Only include vulnerabilities from this source, e.g. NVD
+	SearchText string // Text to search for in findings
+	Sort       SortOptions
+}
+
 // GetAll fetches all findings for a given project.
 func (f FindingService) GetAll(ctx context.Context, projectUUID uuid.UUID, suppressed bool, po PageOptions) (p Page[Finding], err error) {
+	return f.getAll(ctx, "Finding.GetAll", projectUUID, suppressed, po, FindingListOptions{})
+}
+
+// GetAllFiltered fetches the findings for a given project, filtered and sorted on the server according to lo.
+func (f FindingService) GetAllFiltered(ctx context.Context, projectUUID uuid.UUID, suppressed bool, po PageOptions, lo FindingListOptions) (p Page[Finding], err error) {
+	return f.getAll(ctx, "Finding.GetAllFiltered", projectUUID, suppressed, po, lo)
+}
+
+func (f FindingService) getAll(ctx context.Context, operationName string, projectUUID uuid.UUID, suppressed bool, po PageOptions, lo FindingListOptions) (p Page[Finding], err error) {
 	params := map[string]string{
 		"suppressed": strconv.FormatBool(suppressed),
 	}
+	if lo.Source != "" {
+		params["source"] = lo.Source
+	}
+	if lo.SearchText != "" {
+		params["searchText"] = lo.SearchText
+	}
 
-	req, err := f.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/finding/project/%s", projectUUID), withParams(params), withPageOptions(po))
+	req, err := f.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/finding/project/%s", projectUUID),
+		withOperationName(operationName),
+		withParams(params),
+		withSortOptions(lo.Sort),
+		withPageOptions(po))
 	if err != nil {
 		return
 	}
@@ -86,6 +148,20 @@ func (f FindingService) GetAll(ctx context.Context, projectUUID uuid.UUID, suppr
 	return
 }
 
+// All returns an iterator over all items that GetAll would return across all pages.
+func (f FindingService) All(ctx context.Context, projectUUID uuid.UUID, suppressed bool, options ...IterOption) iter.Seq2[Finding, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Finding], error) {
+		return f.GetAll(ctx, projectUUID, suppressed, po)
+	}, options...)
+}
+
+// AllFiltered returns an iterator over all items that GetAllFiltered would return across all pages.
+func (f FindingService) AllFiltered(ctx context.Context, projectUUID uuid.UUID, suppressed bool, lo FindingListOptions, options ...IterOption) iter.Seq2[Finding, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Finding], error) {
+		return f.GetAllFiltered(ctx, projectUUID, suppressed, po, lo)
+	}, options...)
+}
+
 // ExportFPF exports the findings of a given project in the File Packaging Format (FPF).
 func (f FindingService) ExportFPF(ctx context.Context, projectUUID uuid.UUID) (d []byte, err error) {
 	req, err := f.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/finding/project/%s/export", projectUUID))
@@ -105,18 +181,22 @@ func (f FindingService) ExportFPF(ctx context.Context, projectUUID uuid.UUID) (d
 
 // AnalyzeProject triggers an analysis for a given project.
 // This feature is available in Dependency-Track v4.7.0 and newer.
-func (f FindingService) AnalyzeProject(ctx context.Context, projectUUID uuid.UUID) (token BOMUploadToken, err error) {
+func (f FindingService) AnalyzeProject(ctx context.Context, projectUUID uuid.UUID) (token EventToken, err error) {
+	if err = f.client.requireFeature(ctx, FeatureProjectAnalysis); err != nil {
+		return
+	}
+
 	req, err := f.client.newRequest(ctx, http.MethodPost, fmt.Sprintf("/api/v1/finding/project/%s/analyze", projectUUID))
 	if err != nil {
 		return
 	}
 
-	var uploadRes bomUploadResponse
-	_, err = f.client.doRequest(req, &uploadRes)
+	var tokenRes eventTokenResponse
+	_, err = f.client.doRequest(req, &tokenRes)
 	if err != nil {
 		return
 	}
 
-	token = uploadRes.Token
+	token = tokenRes.Token
 	return
 }
@@ -0,0 +1,155 @@
+package dtrack
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScoreRange restricts a score to an inclusive range. Bounds that are zero are not applied.
+type ScoreRange struct {
+	Min float64
+	Max float64
+}
+
+// DateRange restricts a date to an inclusive range. Bounds that are zero are not applied.
+// Only the date part of the bounds is considered.
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// PortfolioFindingListOptions filters and sorts the findings returned by
+// FindingService.GetAllPortfolio and FindingService.GetGrouped.
+type PortfolioFindingListOptions struct {
+	Severities     []Severity      // Only include findings with one of these severities
+	AnalysisStates []AnalysisState // Only include findings with one of these analysis states
+	ShowInactive   bool            // Include findings of inactive projects
+	ShowSuppressed bool            // Include suppressed findings
+	CVSSv2         ScoreRange
+	CVSSv3         ScoreRange
+	EPSS           ScoreRange // EPSS score, between 0 and 1
+	Published      DateRange  // Date the vulnerability was published
+	AttributedOn   DateRange  // Date the vulnerability was attributed to the component
+	Source         string     // Only include vulnerabilities from this source, e.g. NVD
+	SearchText     string     // Text to search for in SearchFields
+	SearchFields   []string   // Fields to search for SearchText, e.g. vulnerability.vulnId or component.name
+	Sort           SortOptions
+}
+
+func (lo PortfolioFindingListOptions) params() map[string]string {
+	params := make(map[string]string)
+
+	if len(lo.Severities) > 0 {
+		severities := make([]string, 0, len(lo.Severities))
+		for _, severity := range lo.Severities {
+			severities = append(severities, string(severity))
+		}
+		params["severity"] = strings.Join(severities, ",")
+	}
+	if len(lo.AnalysisStates) > 0 {
+		states := make([]string, 0, len(lo.AnalysisStates))
+		for _, state := range lo.AnalysisStates {
+			states = append(states, string(state))
+		}
+		params["analysisStatus"] = strings.Join(states, ",")
+	}
+	if lo.ShowInactive {
+		params["showInactive"] = "true"
+	}
+	if lo.ShowSuppressed {
+		params["showSuppressed"] = "true"
+	}
+
+	lo.CVSSv2.setParams(params, "cvssv2From", "cvssv2To")
+	lo.CVSSv3.setParams(params, "cvssv3From", "cvssv3To")
+	lo.EPSS.setParams(params, "epssFrom", "epssTo")
+	lo.Published.setParams(params, "publishDateFrom", "publishDateTo")
+	lo.AttributedOn.setParams(params, "attributedOnDateFrom", "attributedOnDateTo")
+
+	if lo.Source != "" {
+		params["source"] = lo.Source
+	}
+	if lo.SearchText != "" {
+		params["textSearchInput"] = lo.SearchText
+		if len(lo.SearchFields) > 0 {
+			params["textSearchField"] = strings.Join(lo.SearchFields, ",")
+		}
+	}
+
+	return params
+}
+
+func (sr ScoreRange) setParams(params map[string]string, fromParam, toParam string) {
+	if sr.Min != 0 {
+		params[fromParam] = strconv.FormatFloat(sr.Min, 'f', -1, 64)
+	}
+	if sr.Max != 0 {
+		params[toParam] = strconv.FormatFloat(sr.Max, 'f', -1, 64)
+	}
+}
+
+func (dr DateRange) setParams(params map[string]string, fromParam, toParam string) {
+	if !dr.From.IsZero() {
+		params[fromParam] = dr.From.Format(time.DateOnly)
+	}
+	if !dr.To.IsZero() {
+		params[toParam] = dr.To.Format(time.DateOnly)
+	}
+}
+
+// GetAllPortfolio fetches findings across all projects of the portfolio.
+// Findings are filtered and sorted on the server according to lo.
+// This feature is available in Dependency-Track v4.9.0 and newer.
+func (f FindingService) GetAllPortfolio(ctx context.Context, po PageOptions, lo PortfolioFindingListOptions) (p Page[Finding], err error) {
+	return f.getPortfolio(ctx, "Finding.GetAllPortfolio", "/api/v1/finding", po, lo)
+}
+
+// AllPortfolio returns an iterator over all items that GetAllPortfolio would return across all pages.
+func (f FindingService) AllPortfolio(ctx context.Context, lo PortfolioFindingListOptions, options ...IterOption) iter.Seq2[Finding, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Finding], error) {
+		return f.GetAllPortfolio(ctx, po, lo)
+	}, options...)
+}
+
+// GetGrouped fetches findings across all projects of the portfolio, grouped by vulnerability.
+// Only the vulnerability and attribution of the returned findings are populated,
+// FindingVulnerability.AffectedProjectCount is the number of projects affected by the vulnerability.
+// Findings are filtered and sorted on the server according to lo.
+// This feature is available in Dependency-Track v4.9.0 and newer.
+func (f FindingService) GetGrouped(ctx context.Context, po PageOptions, lo PortfolioFindingListOptions) (p Page[Finding], err error) {
+	return f.getPortfolio(ctx, "Finding.GetGrouped", "/api/v1/finding/grouped", po, lo)
+}
+
+// AllGrouped returns an iterator over all items that GetGrouped would return across all pages.
+func (f FindingService) AllGrouped(ctx context.Context, lo PortfolioFindingListOptions, options ...IterOption) iter.Seq2[Finding, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Finding], error) {
+		return f.GetGrouped(ctx, po, lo)
+	}, options...)
+}
+
+func (f FindingService) getPortfolio(ctx context.Context, operationName, path string, po PageOptions, lo PortfolioFindingListOptions) (p Page[Finding], err error) {
+	if err = f.client.requireFeature(ctx, FeaturePortfolioFindings); err != nil {
+		return
+	}
+
+	req, err := f.client.newRequest(ctx, http.MethodGet, path,
+		withOperationName(operationName),
+		withParams(lo.params()),
+		withSortOptions(lo.Sort),
+		withPageOptions(po))
+	if err != nil {
+		return
+	}
+
+	res, err := f.client.doRequest(req, &p.Items)
+	if err != nil {
+		return
+	}
+
+	p.TotalCount = res.TotalCount
+	return
+}
@@ -0,0 +1,164 @@
+package dtrack
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindingService_GetAllFiltered(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	projectUUID := uuid.MustParse("6fb1820f-5280-4577-ac51-40124aabe307")
+
+	var query url.Values
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/finding/project/"+projectUUID.String(),
+		func(req *http.Request) (*http.Response, error) {
+			query = req.URL.Query()
+			return httpmock.NewStringResponse(http.StatusOK, `[]`), nil
+		})
+
+	_, err = client.Finding.GetAllFiltered(context.TODO(), projectUUID, true, PageOptions{}, FindingListOptions{
+		Source: "GITHUB",
+		Sort:   SortOptions{Name: "vulnerability.severity", Order: SortAscending},
+	})
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"suppressed": {"true"},
+		"source":     {"GITHUB"},
+		"sortName":   {"vulnerability.severity"},
+		"sortOrder":  {"asc"},
+	}, query)
+}
+
+func TestFindingService_GetAllPortfolio(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	var (
+		query     url.Values
+		operation string
+	)
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/finding",
+		func(req *http.Request) (*http.Response, error) {
+			query = req.URL.Query()
+			operation = requestOperation(req).String()
+			res := httpmock.NewStringResponse(http.StatusOK, `[{
+				"component": {"name": "log4j-core", "version": "2.14.1", "project": "6fb1820f-5280-4577-ac51-40124aabe307", "projectName": "acme-app", "projectVersion": "1.0.0"},
+				"vulnerability": {"vulnId": "CVE-2021-44228", "source": "NVD", "severity": "CRITICAL", "cvssV3BaseScore": 10.0, "epssScore": 0.97}
+			}]`)
+			res.Header.Set("X-Total-Count", "21")
+			return res, nil
+		})
+
+	page, err := client.Finding.GetAllPortfolio(context.TODO(), PageOptions{PageNumber: 1, PageSize: 20}, PortfolioFindingListOptions{
+		Severities:     []Severity{SeverityCritical, SeverityHigh},
+		AnalysisStates: []AnalysisState{AnalysisStateNotSet, AnalysisStateInTriage},
+		ShowInactive:   true,
+		CVSSv3:         ScoreRange{Min: 7.5},
+		EPSS:           ScoreRange{Min: 0.1, Max: 1},
+		Published:      DateRange{From: time.Date(2021, time.December, 1, 0, 0, 0, 0, time.UTC)},
+		AttributedOn:   DateRange{To: time.Date(2024, time.June, 30, 23, 59, 0, 0, time.UTC)},
+		Source:         "NVD",
+		SearchText:     "log4j",
+		SearchFields:   []string{"component.name", "vulnerability.vulnId"},
+		Sort:           SortOptions{Name: "vulnerability.cvssV3BaseScore", Order: SortDescending},
+	})
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"severity":           {"CRITICAL,HIGH"},
+		"analysisStatus":     {"NOT_SET,IN_TRIAGE"},
+		"showInactive":       {"true"},
+		"cvssv3From":         {"7.5"},
+		"epssFrom":           {"0.1"},
+		"epssTo":             {"1"},
+		"publishDateFrom":    {"2021-12-01"},
+		"attributedOnDateTo": {"2024-06-30"},
+		"source":             {"NVD"},
+		"textSearchInput":    {"log4j"},
+		"textSearchField":    {"component.name,vulnerability.vulnId"},
+		"sortName":           {"vulnerability.cvssV3BaseScore"},
+		"sortOrder":          {"desc"},
+		"pageNumber":         {"1"},
+		"pageSize":           {"20"},
+	}, query)
+	require.Equal(t, "dtrack.Finding.GetAllPortfolio", operation)
+
+	require.Equal(t, 21, page.TotalCount)
+	require.Len(t, page.Items, 1)
+	require.Equal(t, "acme-app", page.Items[0].Component.ProjectName)
+	require.Equal(t, "1.0.0", page.Items[0].Component.ProjectVersion)
+	require.Equal(t, 0.97, page.Items[0].Vulnerability.EPSSScore)
+}
+
+func TestFindingService_GetGrouped(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	var (
+		query     url.Values
+		operation string
+	)
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/finding/grouped",
+		func(req *http.Request) (*http.Response, error) {
+			query = req.URL.Query()
+			operation = requestOperation(req).String()
+			res := httpmock.NewStringResponse(http.StatusOK, `[{
+				"vulnerability": {"vulnId": "CVE-2021-44228", "source": "NVD", "severity": "CRITICAL", "affectedProjectCount": 3},
+				"attribution": {"analyzerIdentity": "INTERNAL_ANALYZER"}
+			}]`)
+			res.Header.Set("X-Total-Count", "1")
+			return res, nil
+		})
+
+	var findings []Finding
+	for finding, err := range client.Finding.AllGrouped(context.TODO(), PortfolioFindingListOptions{ShowSuppressed: true}) {
+		require.NoError(t, err)
+		findings = append(findings, finding)
+	}
+	require.Len(t, findings, 1)
+	require.Equal(t, 3, findings[0].Vulnerability.AffectedProjectCount)
+	require.Equal(t, "INTERNAL_ANALYZER", findings[0].Attribution.AnalyzerIdentity)
+	require.Equal(t, "true", query.Get("showSuppressed"))
+	require.Equal(t, "dtrack.Finding.GetGrouped", operation)
+}
+
+func TestFindingService_GetAllPortfolio_Unsupported(t *testing.T) {
+	client, err := NewClient("http://localhost", WithServerVersionCheck())
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+		httpmock.NewStringResponder(http.StatusOK, `{"version":"4.8.2"}`))
+
+	_, err = client.Finding.GetAllPortfolio(context.TODO(), PageOptions{}, PortfolioFindingListOptions{})
+	require.ErrorIs(t, err, ErrUnsupportedByServer)
+
+	_, err = client.Finding.GetGrouped(context.TODO(), PageOptions{}, PortfolioFindingListOptions{})
+	require.ErrorIs(t, err, ErrUnsupportedByServer)
+}
+
+func TestSeverity_Rank(t *testing.T) {
+	for rank, severity := range []Severity{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo, SeverityUnassigned} {
+		require.Equal(t, rank, severity.Rank(), severity)
+	}
+	require.Greater(t, Severity("UNKNOWN").Rank(), SeverityUnassigned.Rank())
+}
@@ -0,0 +1,17 @@
+// Package gate evaluates the findings and policy violations of a Dependency-Track project
+// against a set of Rules, e.g. to fail a CI build when a project is affected by critical vulnerabilities.
+//
+// Only unsuppressed findings are considered. Rules decide which of them are counted, and how many
+// counted findings of each severity are acceptable. Policy violations fail the gate if their policy's
+// violation state is one of Rules.ViolationStates, unless the violation was approved:
+//
+//	verdict, err := gate.Evaluate(ctx, client, projectUUID, gate.Rules{
+//		MaxFindings:     map[dtrack.Severity]int{dtrack.SeverityCritical: 0, dtrack.SeverityHigh: 5},
+//		GracePeriod:     7 * 24 * time.Hour,
+//		ViolationStates: []dtrack.PolicyViolationState{dtrack.PolicyViolationStateFail},
+//	})
+//	fmt.Print(verdict)
+//	os.Exit(gate.ExitCode(verdict, err))
+//
+// Check applies rules to findings and policy violations that have already been fetched.
+package gate
@@ -0,0 +1,333 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+	"github.com/futurice/dependency-track-client-go/internal/packageurl"
+)
+
+// Exit codes returned by ExitCode.
+const (
+	ExitCodePassed = 0
+	ExitCodeFailed = 1
+	ExitCodeError  = 2
+)
+
+// Names of the rules reported in Failure.Rule.
+const (
+	RuleMaxFindings      = "max_findings"
+	RulePolicyViolations = "policy_violations"
+)
+
+// severities lists the severities in the order they are reported, most severe first.
+var severities = []dtrack.Severity{
+	dtrack.SeverityCritical,
+	dtrack.SeverityHigh,
+	dtrack.SeverityMedium,
+	dtrack.SeverityLow,
+	dtrack.SeverityInfo,
+	dtrack.SeverityUnassigned,
+}
+
+// Rules declares which findings and policy violations fail the gate.
+// The zero value passes every project.
+type Rules struct {
+	// MaxFindings is the maximum number of counted findings per severity.
+	// Severities that are not listed are not limited, a limit of 0 doesn't allow any findings.
+	MaxFindings map[dtrack.Severity]int
+
+	// MinEPSS is the minimum EPSS score of counted findings.
+	// Findings without an EPSS score are always counted, as their exploitability is unknown.
+	MinEPSS float64
+
+	AllowedAnalysisStates []dtrack.AnalysisState // Findings analysed with one of these states are not counted
+	GracePeriod           time.Duration          // Findings attributed more recently than this are not counted
+
+	// ViolationStates lists the violation states of policies whose violations fail the gate.
+	// Policy violations are not fetched if it is empty.
+	ViolationStates []dtrack.PolicyViolationState
+
+	Ignore IgnoreList
+}
+
+// IgnoreList lists findings and policy violations that are never counted.
+type IgnoreList struct {
+	// Vulnerabilities are matched against the ID of a vulnerability and its aliases, e.g. CVE-2021-44228.
+	Vulnerabilities []string
+
+	// Components are matched against the package URL of a component, with or without its version,
+	// or against its coordinates in the form group/name, or name if it has no group.
+	Components []string
+
+	// Policies are matched against the name of the violated policy.
+	Policies []string
+}
+
+// Validate reports whether the rules are valid.
+func (r Rules) Validate() error {
+	for severity, limit := range r.MaxFindings {
+		if limit < 0 {
+			return fmt.Errorf("max findings of severity %s must not be negative", severity)
+		}
+	}
+	if r.MinEPSS < 0 || r.MinEPSS > 1 {
+		return fmt.Errorf("min EPSS must be between 0 and 1")
+	}
+	if r.GracePeriod < 0 {
+		return fmt.Errorf("grace period must not be negative")
+	}
+
+	return nil
+}
+
+// Failure describes a rule that was broken.
+type Failure struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Verdict is the result of evaluating rules for a project.
+type Verdict struct {
+	ProjectUUID uuid.UUID `json:"projectUuid"`
+	Passed      bool      `json:"passed"`
+	Failures    []Failure `json:"failures,omitempty"`
+
+	// Counts is the number of counted findings per severity.
+	Counts map[dtrack.Severity]int `json:"counts"`
+
+	// OffendingFindings are the counted findings of the severities whose limit was exceeded.
+	OffendingFindings []dtrack.Finding `json:"offendingFindings,omitempty"`
+
+	OffendingViolations []dtrack.PolicyViolation `json:"offendingViolations,omitempty"`
+}
+
+// String summarizes the verdict for humans, one broken rule per line.
+func (v Verdict) String() string {
+	var sb strings.Builder
+
+	for _, f := range v.Failures {
+		fmt.Fprintf(&sb, "%s: %s\n", f.Rule, f.Message)
+	}
+
+	counts := make([]string, 0, len(severities))
+	for _, severity := range severities {
+		counts = append(counts, fmt.Sprintf("%d %s", v.Counts[severity], strings.ToLower(string(severity))))
+	}
+
+	result := "passed"
+	if !v.Passed {
+		result = "failed"
+	}
+	fmt.Fprintf(&sb, "gate %s: %s\n", result, strings.Join(counts, ", "))
+
+	return sb.String()
+}
+
+// ExitCode maps the result of Evaluate to a process exit code: ExitCodeError if err is not nil,
+// ExitCodeFailed if the gate failed, and ExitCodePassed otherwise.
+func ExitCode(v Verdict, err error) int {
+	switch {
+	case err != nil:
+		return ExitCodeError
+	case !v.Passed:
+		return ExitCodeFailed
+	default:
+		return ExitCodePassed
+	}
+}
+
+// Evaluate fetches the unsuppressed findings and policy violations of a project, and checks them against rules.
+func Evaluate(ctx context.Context, client *dtrack.Client, projectUUID uuid.UUID, rules Rules) (v Verdict, err error) {
+	if err = rules.Validate(); err != nil {
+		return
+	}
+
+	var findings []dtrack.Finding
+	for finding, err := range client.Finding.All(ctx, projectUUID, false) {
+		if err != nil {
+			return Verdict{}, fmt.Errorf("failed to fetch findings: %w", err)
+		}
+		findings = append(findings, finding)
+	}
+
+	var violations []dtrack.PolicyViolation
+	if len(rules.ViolationStates) > 0 {
+		for violation, err := range client.PolicyViolation.AllForProject(ctx, projectUUID, false) {
+			if err != nil {
+				return Verdict{}, fmt.Errorf("failed to fetch policy violations: %w", err)
+			}
+			violations = append(violations, violation)
+		}
+	}
+
+	v = Check(rules, findings, violations, time.Now())
+	v.ProjectUUID = projectUUID
+	return
+}
+
+// Check checks findings and policy violations against rules. The grace period is measured until now.
+// Suppressed findings and policy violations are not counted.
+func Check(rules Rules, findings []dtrack.Finding, violations []dtrack.PolicyViolation, now time.Time) Verdict {
+	v := Verdict{
+		Counts: make(map[dtrack.Severity]int),
+	}
+
+	counted := make(map[dtrack.Severity][]dtrack.Finding)
+	for _, finding := range findings {
+		if !rules.counts(finding, now) {
+			continue
+		}
+
+		severity := findingSeverity(finding)
+		v.Counts[severity]++
+		counted[severity] = append(counted[severity], finding)
+	}
+
+	for _, severity := range severities {
+		limit, ok := rules.MaxFindings[severity]
+		if !ok || v.Counts[severity] <= limit {
+			continue
+		}
+
+		exceed := plural(v.Counts[severity], "finding exceeds", "findings exceed")
+		v.Failures = append(v.Failures, Failure{
+			Rule:    RuleMaxFindings,
+			Message: fmt.Sprintf("%d %s %s the limit of %d", v.Counts[severity], strings.ToLower(string(severity)), exceed, limit),
+		})
+		v.OffendingFindings = append(v.OffendingFindings, counted[severity]...)
+	}
+
+	for _, violation := range violations {
+		if rules.violates(violation) {
+			v.OffendingViolations = append(v.OffendingViolations, violation)
+		}
+	}
+	if len(v.OffendingViolations) > 0 {
+		v.Failures = append(v.Failures, Failure{
+			Rule:    RulePolicyViolations,
+			Message: fmt.Sprintf("%d %s", len(v.OffendingViolations), plural(len(v.OffendingViolations), "policy violation", "policy violations")),
+		})
+	}
+
+	v.Passed = len(v.Failures) == 0
+	return v
+}
+
+func (r Rules) counts(finding dtrack.Finding, now time.Time) bool {
+	if finding.Analysis.Suppressed {
+		return false
+	}
+	if epss := finding.Vulnerability.EPSSScore; epss > 0 && epss < r.MinEPSS {
+		return false
+	}
+
+	state := dtrack.AnalysisState(finding.Analysis.State)
+	if state == "" {
+		state = dtrack.AnalysisStateNotSet
+	}
+	if slices.Contains(r.AllowedAnalysisStates, state) {
+		return false
+	}
+
+	if r.GracePeriod > 0 && finding.Attribution.AttributedOn > 0 {
+		attributedOn := time.UnixMilli(int64(finding.Attribution.AttributedOn))
+		if now.Sub(attributedOn) < r.GracePeriod {
+			return false
+		}
+	}
+
+	if r.Ignore.matchesVulnerability(finding.Vulnerability) {
+		return false
+	}
+	if r.Ignore.matchesComponent(finding.Component.PURL, finding.Component.Group, finding.Component.Name) {
+		return false
+	}
+
+	return true
+}
+
+func (r Rules) violates(violation dtrack.PolicyViolation) bool {
+	if violation.PolicyCondition == nil || violation.PolicyCondition.Policy == nil {
+		return false
+	}
+	policy := violation.PolicyCondition.Policy
+
+	if !slices.Contains(r.ViolationStates, policy.ViolationState) {
+		return false
+	}
+	if analysis := violation.Analysis; analysis != nil {
+		if analysis.Suppressed || analysis.State == dtrack.ViolationAnalysisStateApproved {
+			return false
+		}
+	}
+
+	if slices.Contains(r.Ignore.Policies, policy.Name) {
+		return false
+	}
+	if r.Ignore.matchesComponent(violation.Component.PURL, violation.Component.Group, violation.Component.Name) {
+		return false
+	}
+
+	return true
+}
+
+func findingSeverity(finding dtrack.Finding) dtrack.Severity {
+	if finding.Vulnerability.Severity == "" {
+		return dtrack.SeverityUnassigned
+	}
+	return dtrack.Severity(strings.ToUpper(finding.Vulnerability.Severity))
+}
+
+func (il IgnoreList) matchesVulnerability(vuln dtrack.FindingVulnerability) bool {
+	for _, id := range il.Vulnerabilities {
+		if strings.EqualFold(id, vuln.VulnID) {
+			return true
+		}
+
+		for _, alias := range vuln.Aliases {
+			for _, aliasID := range []string{alias.CveID, alias.GhsaID, alias.GsdID, alias.InternalID, alias.OsvID, alias.SonatypeId, alias.SnykID, alias.VulnDbID} {
+				if aliasID != "" && strings.EqualFold(id, aliasID) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func (il IgnoreList) matchesComponent(purl, group, name string) bool {
+	coordinates := name
+	if group != "" {
+		coordinates = group + "/" + name
+	}
+
+	// Strip qualifiers, subpath and version from the package URL.
+	unversioned, _ := packageurl.CutQualifiers(purl)
+	unversioned, _, _ = packageurl.CutVersion(unversioned)
+
+	for _, component := range il.Components {
+		switch component {
+		case "":
+			continue
+		case purl, unversioned, coordinates:
+			return true
+		}
+	}
+
+	return false
+}
+
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
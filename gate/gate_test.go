@@ -0,0 +1,213 @@
+package gate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+	"github.com/futurice/dependency-track-client-go/dtracktest"
+)
+
+// Findings are attributed 30 days before they are checked, unless a test overrides it.
+var (
+	checkedAt    = time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	attributedOn = time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC)
+)
+
+func finding(vulnID string, severity dtrack.Severity, purl string) dtrack.Finding {
+	return dtrack.Finding{
+		Attribution:   dtrack.FindingAttribution{AttributedOn: int(attributedOn.UnixMilli())},
+		Component:     dtrack.FindingComponent{PURL: purl},
+		Vulnerability: dtrack.FindingVulnerability{VulnID: vulnID, Severity: string(severity), EPSSScore: 0.5},
+	}
+}
+
+func violation(policyName string, state dtrack.PolicyViolationState) dtrack.PolicyViolation {
+	return dtrack.PolicyViolation{
+		Component:       dtrack.Component{Group: "org.acme", Name: "acme-lib"},
+		PolicyCondition: &dtrack.PolicyCondition{Policy: &dtrack.Policy{Name: policyName, ViolationState: state}},
+		Type:            "LICENSE",
+	}
+}
+
+func TestCheck(t *testing.T) {
+	critical := finding("CVE-2021-44228", dtrack.SeverityCritical, "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1")
+
+	inTriage := finding("CVE-2022-22965", dtrack.SeverityCritical, "pkg:maven/org.springframework/spring-beans@5.3.17")
+	inTriage.Analysis.State = string(dtrack.AnalysisStateInTriage)
+	notAffected := finding("CVE-2022-22965", dtrack.SeverityCritical, "pkg:maven/org.springframework/spring-core@5.3.17")
+	notAffected.Analysis.State = string(dtrack.AnalysisStateNotAffected)
+
+	recent := finding("CVE-2024-0001", dtrack.SeverityCritical, "pkg:npm/acme@1.0.0")
+	recent.Attribution.AttributedOn = int(checkedAt.AddDate(0, 0, -2).UnixMilli())
+
+	improbable := finding("CVE-2024-0002", dtrack.SeverityCritical, "pkg:npm/acme@1.0.0")
+	improbable.Vulnerability.EPSSScore = 0.001
+
+	aliased := finding("GHSA-jfh8-c2jp-5v3q", dtrack.SeverityCritical, "pkg:npm/acme@1.0.0")
+	aliased.Vulnerability.Aliases = []dtrack.VulnerabilityAlias{{CveID: "CVE-2024-0003"}}
+
+	ignoredComponent := finding("CVE-2024-0004", dtrack.SeverityCritical, "pkg:npm/left-pad@1.3.0?repository_url=https://registry.npmjs.org")
+	ignoredScoped := finding("CVE-2024-0008", dtrack.SeverityCritical, "pkg:npm/@angular/core@1.0.0")
+	otherScope := finding("CVE-2024-0009", dtrack.SeverityHigh, "pkg:npm/@angular/common@1.0.0")
+
+	high := finding("CVE-2024-0005", dtrack.SeverityHigh, "pkg:npm/acme@1.0.0")
+	low := finding("CVE-2024-0006", dtrack.SeverityLow, "pkg:npm/acme@1.0.0")
+	unassigned := finding("CVE-2024-0007", "", "pkg:npm/acme@1.0.0")
+
+	fail := violation("Forbidden licenses", dtrack.PolicyViolationStateFail)
+	warn := violation("Outdated components", dtrack.PolicyViolationStateWarn)
+	approved := violation("Forbidden licenses", dtrack.PolicyViolationStateFail)
+	approved.Analysis = &dtrack.ViolationAnalysis{State: dtrack.ViolationAnalysisStateApproved}
+	ignoredPolicy := violation("Experimental", dtrack.PolicyViolationStateFail)
+
+	rules := Rules{
+		MaxFindings:           map[dtrack.Severity]int{dtrack.SeverityCritical: 0, dtrack.SeverityHigh: 1, dtrack.SeverityLow: 0},
+		MinEPSS:               0.01,
+		AllowedAnalysisStates: []dtrack.AnalysisState{dtrack.AnalysisStateNotAffected, dtrack.AnalysisStateFalsePositive},
+		GracePeriod:           7 * 24 * time.Hour,
+		ViolationStates:       []dtrack.PolicyViolationState{dtrack.PolicyViolationStateFail},
+		Ignore: IgnoreList{
+			Vulnerabilities: []string{"cve-2024-0003"},
+			Components:      []string{"pkg:npm/left-pad", "pkg:npm/@angular/core"},
+			Policies:        []string{"Experimental"},
+		},
+	}
+	require.NoError(t, rules.Validate())
+
+	v := Check(rules,
+		[]dtrack.Finding{critical, inTriage, notAffected, recent, improbable, aliased, ignoredComponent, ignoredScoped, otherScope, high, low, unassigned},
+		[]dtrack.PolicyViolation{fail, warn, approved, ignoredPolicy},
+		checkedAt)
+
+	require.False(t, v.Passed)
+	require.Equal(t, map[dtrack.Severity]int{
+		dtrack.SeverityCritical:   2,
+		dtrack.SeverityHigh:       2,
+		dtrack.SeverityLow:        1,
+		dtrack.SeverityUnassigned: 1,
+	}, v.Counts)
+	require.Equal(t, []Failure{
+		{Rule: RuleMaxFindings, Message: "2 critical findings exceed the limit of 0"},
+		{Rule: RuleMaxFindings, Message: "2 high findings exceed the limit of 1"},
+		{Rule: RuleMaxFindings, Message: "1 low finding exceeds the limit of 0"},
+		{Rule: RulePolicyViolations, Message: "1 policy violation"},
+	}, v.Failures)
+	require.Equal(t, []dtrack.Finding{critical, inTriage, otherScope, high, low}, v.OffendingFindings)
+	require.Equal(t, []dtrack.PolicyViolation{fail}, v.OffendingViolations)
+	require.Equal(t, ExitCodeFailed, ExitCode(v, nil))
+
+	require.Equal(t, `max_findings: 2 critical findings exceed the limit of 0
+max_findings: 2 high findings exceed the limit of 1
+max_findings: 1 low finding exceeds the limit of 0
+policy_violations: 1 policy violation
+gate failed: 2 critical, 2 high, 0 medium, 1 low, 0 info, 1 unassigned
+`, v.String())
+
+	v = Check(Rules{}, []dtrack.Finding{critical, high}, []dtrack.PolicyViolation{fail}, checkedAt)
+	require.True(t, v.Passed)
+	require.Empty(t, v.Failures)
+	require.Equal(t, ExitCodePassed, ExitCode(v, nil))
+}
+
+func TestCheck_UnscoredFinding(t *testing.T) {
+	unscored := finding("CVE-2024-0010", dtrack.SeverityCritical, "pkg:npm/acme@1.0.0")
+	unscored.Vulnerability.EPSSScore = 0
+
+	v := Check(Rules{
+		MaxFindings: map[dtrack.Severity]int{dtrack.SeverityCritical: 0},
+		MinEPSS:     0.1,
+	}, []dtrack.Finding{unscored}, nil, checkedAt)
+
+	require.False(t, v.Passed)
+	require.Equal(t, map[dtrack.Severity]int{dtrack.SeverityCritical: 1}, v.Counts)
+	require.Equal(t, []dtrack.Finding{unscored}, v.OffendingFindings)
+}
+
+func TestRules_Validate(t *testing.T) {
+	require.Error(t, Rules{MaxFindings: map[dtrack.Severity]int{dtrack.SeverityHigh: -1}}.Validate())
+	require.Error(t, Rules{MinEPSS: 1.5}.Validate())
+	require.Error(t, Rules{GracePeriod: -time.Hour}.Validate())
+}
+
+func TestEvaluate(t *testing.T) {
+	server := dtracktest.NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	project := server.AddProject(dtrack.Project{Name: "acme-app", Version: "1.0.0", Active: true})
+	component := server.AddComponent(project.UUID, dtrack.Component{Name: "log4j-core", Version: "2.14.1"})
+
+	critical := finding("CVE-2021-44228", dtrack.SeverityCritical, "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1")
+	critical.Component.UUID = component.UUID
+	suppressed := finding("CVE-2021-45046", dtrack.SeverityCritical, "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1")
+	suppressed.Component.UUID = component.UUID
+	suppressed.Analysis.Suppressed = true
+	server.AddFinding(project.UUID, critical)
+	server.AddFinding(project.UUID, suppressed)
+
+	fail := violation("Forbidden licenses", dtrack.PolicyViolationStateFail)
+	server.AddPolicyViolation(project.UUID, fail)
+	suppressedViolation := violation("Forbidden licenses", dtrack.PolicyViolationStateFail)
+	suppressedViolation.Analysis = &dtrack.ViolationAnalysis{State: dtrack.ViolationAnalysisStateRejected, Suppressed: true}
+	server.AddPolicyViolation(project.UUID, suppressedViolation)
+
+	rules := Rules{
+		MaxFindings:     map[dtrack.Severity]int{dtrack.SeverityCritical: 0},
+		ViolationStates: []dtrack.PolicyViolationState{dtrack.PolicyViolationStateFail},
+	}
+
+	v, err := Evaluate(context.TODO(), client, project.UUID, rules)
+	require.NoError(t, err)
+	require.Equal(t, project.UUID, v.ProjectUUID)
+	require.False(t, v.Passed)
+	require.Equal(t, 1, v.Counts[dtrack.SeverityCritical])
+	require.Len(t, v.OffendingFindings, 1)
+	require.Equal(t, "CVE-2021-44228", v.OffendingFindings[0].Vulnerability.VulnID)
+	require.Len(t, v.OffendingViolations, 1)
+	require.Equal(t, "Forbidden licenses", v.OffendingViolations[0].PolicyCondition.Policy.Name)
+
+	content, err := json.Marshal(v)
+	require.NoError(t, err)
+	var decoded Verdict
+	require.NoError(t, json.Unmarshal(content, &decoded))
+	require.Equal(t, v.Failures, decoded.Failures)
+	require.Equal(t, v.Counts, decoded.Counts)
+
+	rules.Ignore.Vulnerabilities = []string{"CVE-2021-44228"}
+	rules.ViolationStates = nil
+	v, err = Evaluate(context.TODO(), client, project.UUID, rules)
+	require.NoError(t, err)
+	require.True(t, v.Passed)
+}
+
+func TestEvaluate_Error(t *testing.T) {
+	server := dtracktest.NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	project := server.AddProject(dtrack.Project{Name: "acme-app", Version: "1.0.0", Active: true})
+
+	server.InjectFailure(dtracktest.Failure{
+		Method:     http.MethodGet,
+		PathPrefix: "/api/v1/violation/project/" + project.UUID.String(),
+		StatusCode: http.StatusForbidden,
+	})
+
+	v, err := Evaluate(context.TODO(), client, project.UUID, Rules{ViolationStates: []dtrack.PolicyViolationState{dtrack.PolicyViolationStateFail}})
+	require.ErrorIs(t, err, dtrack.ErrForbidden)
+	require.ErrorContains(t, err, "failed to fetch policy violations")
+	require.Equal(t, ExitCodeError, ExitCode(v, err))
+
+	_, err = Evaluate(context.TODO(), client, project.UUID, Rules{MinEPSS: 2})
+	require.Error(t, err)
+}
@@ -0,0 +1,35 @@
+// Package packageurl splits package URLs into their parts,
+// as far as it is required to compare components independently of their version.
+// See https://github.com/package-url/purl-spec for the syntax of package URLs.
+package packageurl
+
+import "strings"
+
+// CutQualifiers slices purl around its qualifiers and subpath. It returns the text before them,
+// and the qualifiers and subpath including their leading ? or #.
+func CutQualifiers(purl string) (before, qualifiers string) {
+	if i := strings.IndexAny(purl, "?#"); i >= 0 {
+		return purl[:i], purl[i:]
+	}
+
+	return purl, ""
+}
+
+// CutVersion slices purl, which must not have qualifiers or a subpath, around the separator of its version.
+// The separator is the last @ after the last /, as unencoded namespaces may start with @ too,
+// e.g. pkg:npm/@angular/core@1.0.0. found is false if purl has no version.
+func CutVersion(purl string) (before, version string, found bool) {
+	if i := strings.LastIndexByte(purl, '@'); i > strings.LastIndexByte(purl, '/') {
+		return purl[:i], purl[i+1:], true
+	}
+
+	return purl, "", false
+}
+
+// WithoutVersion removes the version from purl, keeping its qualifiers and subpath.
+func WithoutVersion(purl string) string {
+	before, qualifiers := CutQualifiers(purl)
+	before, _, _ = CutVersion(before)
+
+	return before + qualifiers
+}
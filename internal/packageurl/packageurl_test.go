@@ -0,0 +1,31 @@
+package packageurl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCutVersion(t *testing.T) {
+	before, version, found := CutVersion("pkg:npm/@angular/core@1.0.0")
+	require.True(t, found)
+	require.Equal(t, "pkg:npm/@angular/core", before)
+	require.Equal(t, "1.0.0", version)
+
+	before, _, found = CutVersion("pkg:npm/@angular/core")
+	require.False(t, found)
+	require.Equal(t, "pkg:npm/@angular/core", before)
+}
+
+func TestWithoutVersion(t *testing.T) {
+	for purl, want := range map[string]string{
+		"pkg:maven/org.acme/foo@1.0.0?type=jar": "pkg:maven/org.acme/foo?type=jar",
+		"pkg:npm/%40angular/core@1.0.0":         "pkg:npm/%40angular/core",
+		"pkg:npm/@angular/core@1.0.0":           "pkg:npm/@angular/core",
+		"pkg:npm/@angular/core":                 "pkg:npm/@angular/core",
+		"pkg:golang/example.com/foo@v1.0.0#sub": "pkg:golang/example.com/foo#sub",
+		"pkg:generic/foo?download_url=a@b":      "pkg:generic/foo?download_url=a@b",
+	} {
+		require.Equal(t, want, WithoutVersion(purl), purl)
+	}
+}
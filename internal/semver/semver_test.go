@@ -0,0 +1,31 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	v, err := Parse("v4.8.0-SNAPSHOT+build.1")
+	require.NoError(t, err)
+	require.Equal(t, Version{Major: 4, Minor: 8, Patch: 0, PreRelease: "SNAPSHOT"}, v)
+
+	v, err = Parse("4.10")
+	require.NoError(t, err)
+	require.Equal(t, Version{Major: 4, Minor: 10}, v)
+
+	_, err = Parse("latest")
+	require.Error(t, err)
+
+	_, err = Parse("1.2.3.4")
+	require.Error(t, err)
+}
+
+func TestVersion_Compare(t *testing.T) {
+	require.Equal(t, -1, MustParse("4.7.1").Compare(MustParse("4.10.0")))
+	require.Equal(t, 1, MustParse("5.0.0").Compare(MustParse("4.99.99")))
+	require.Equal(t, 0, MustParse("4.8.0").Compare(MustParse("v4.8.0")))
+	require.Equal(t, -1, MustParse("4.8.0-SNAPSHOT").Compare(MustParse("4.8.0")))
+	require.Equal(t, -1, MustParse("1.0.0-alpha").Compare(MustParse("1.0.0-beta")))
+}
@@ -2,6 +2,7 @@ package dtrack
 
 import (
 	"context"
+	"iter"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -39,3 +40,10 @@ func (l LicenseService) GetAll(ctx context.Context, po PageOptions) (p Page[Lice
 	p.TotalCount = res.TotalCount
 	return
 }
+
+// All returns an iterator over all items that GetAll would return across all pages.
+func (l LicenseService) All(ctx context.Context, options ...IterOption) iter.Seq2[License, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[License], error) {
+		return l.GetAll(ctx, po)
+	}, options...)
+}
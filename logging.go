@@ -0,0 +1,217 @@
+package dtrack
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const redacted = "REDACTED"
+
+// sensitiveHeaders are never logged in plain text.
+var sensitiveHeaders = []string{"Authorization", "X-Api-Key", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+// sensitiveFields are form fields and JSON object keys whose values are never logged in plain text.
+var sensitiveFields = []string{"password", "newPassword", "confirmPassword", "key"}
+
+// sensitiveJSONFieldsRegex matches sensitive JSON string fields.
+// A regex is used instead of decoding, so that truncated bodies can be redacted as well.
+var sensitiveJSONFieldsRegex = regexp.MustCompile(`(?i)"(` + strings.Join(sensitiveFields, "|") + `)"\s*:\s*"(?:[^"\\]|\\.)*"?`)
+
+// sensitiveResponsePaths are paths whose responses consist entirely of secrets, e.g. access tokens.
+var sensitiveResponsePaths = []string{"/api/v1/user/login", "/api/v1/user/oidc/login"}
+
+// WithLogger enables structured logging of requests using logger.
+//
+// For every attempt of a request, method, path, status, duration, attempt number and response size
+// are logged. Successful requests are logged on debug level, failed ones on warn level.
+// Authentication headers and credentials are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.logger = logger
+		return nil
+	}
+}
+
+// WithBodyLogging enables logging of request and response bodies, truncated to maxBytes.
+// It only takes effect in combination with WithLogger. Credentials are redacted.
+func WithBodyLogging(maxBytes int) ClientOption {
+	return func(c *Client) error {
+		if maxBytes < 0 {
+			return fmt.Errorf("max bytes must not be negative")
+		}
+
+		c.logBodyLimit = maxBytes
+		return nil
+	}
+}
+
+// logRequest logs the outcome of a single request attempt.
+// When the response was received successfully, logging is deferred until its body has been closed,
+// so that the response size is known.
+func (c Client) logRequest(req *http.Request, attempt int, start time.Time, res *http.Response, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+	}
+
+	if c.logBodyLimit > 0 {
+		attrs = append(attrs,
+			slog.Any("request_headers", redactHeaders(req.Header)),
+			slog.String("request_body", c.requestBodyForLog(req)))
+	}
+
+	if err != nil {
+		attrs = append(attrs,
+			slog.Duration("duration", time.Since(start)),
+			slog.String("error", err.Error()))
+		c.logger.LogAttrs(req.Context(), slog.LevelWarn, "request failed", attrs...)
+		return
+	}
+
+	level := slog.LevelDebug
+	if res.StatusCode >= 400 {
+		level = slog.LevelWarn
+	}
+	attrs = append(attrs, slog.Int("status", res.StatusCode))
+
+	res.Body = &loggingBody{
+		ReadCloser: res.Body,
+		limit:      c.logBodyLimit,
+		onClose: func(size int64, body []byte, truncated bool) {
+			attrs = append(attrs,
+				slog.Duration("duration", time.Since(start)),
+				slog.Int64("response_size", size))
+			if c.logBodyLimit > 0 {
+				attrs = append(attrs,
+					slog.Any("response_headers", redactHeaders(res.Header)),
+					slog.String("response_body", responseBodyForLog(req, res, body, truncated)))
+			}
+			c.logger.LogAttrs(req.Context(), level, "request completed", attrs...)
+		},
+	}
+}
+
+func (c Client) requestBodyForLog(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody {
+		return ""
+	}
+	if req.GetBody == nil {
+		return "<streamed>"
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(body, int64(c.logBodyLimit)+1))
+	if err != nil {
+		return ""
+	}
+
+	truncated := len(content) > c.logBodyLimit
+	if truncated {
+		content = content[:c.logBodyLimit]
+	}
+
+	return redactBody(req.Header.Get("Content-Type"), content, truncated)
+}
+
+func responseBodyForLog(req *http.Request, res *http.Response, body []byte, truncated bool) string {
+	for _, path := range sensitiveResponsePaths {
+		if strings.HasSuffix(req.URL.Path, path) {
+			return redacted
+		}
+	}
+
+	return redactBody(res.Header.Get("Content-Type"), body, truncated)
+}
+
+func redactHeaders(header http.Header) http.Header {
+	redactedHeader := header.Clone()
+	for _, name := range sensitiveHeaders {
+		if redactedHeader.Get(name) != "" {
+			redactedHeader.Set(name, redacted)
+		}
+	}
+
+	return redactedHeader
+}
+
+func redactBody(contentType string, body []byte, truncated bool) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	var content string
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			content = redacted
+			break
+		}
+		for _, field := range sensitiveFields {
+			if values.Has(field) {
+				values.Set(field, redacted)
+			}
+		}
+		content = values.Encode()
+	case "multipart/form-data":
+		content = "<multipart>"
+	default:
+		content = sensitiveJSONFieldsRegex.ReplaceAllString(string(body), `"$1":"`+redacted+`"`)
+	}
+
+	if truncated {
+		content += "...(truncated)"
+	}
+
+	return content
+}
+
+// loggingBody counts the bytes read from a response body,
+// and retains up to limit bytes of it.
+type loggingBody struct {
+	io.ReadCloser
+	limit   int
+	size    int64
+	buf     bytes.Buffer
+	onClose func(size int64, body []byte, truncated bool)
+	once    sync.Once
+}
+
+func (b *loggingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += int64(n)
+
+	if remaining := b.limit - b.buf.Len(); remaining > 0 && n > 0 {
+		if n < remaining {
+			remaining = n
+		}
+		b.buf.Write(p[:remaining])
+	}
+
+	return n, err
+}
+
+func (b *loggingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.onClose(b.size, b.buf.Bytes(), b.size > int64(b.buf.Len()))
+	})
+	return err
+}
@@ -0,0 +1,97 @@
+package dtrack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLogger(t *testing.T) {
+	var logBuf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logBuf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := NewClient("http://localhost", WithLogger(logger), WithBodyLogging(1024))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	t.Run("RedactsLoginCredentials", func(t *testing.T) {
+		logBuf.Reset()
+		httpmock.RegisterResponder(http.MethodPost, "http://localhost/api/v1/user/login",
+			httpmock.NewStringResponder(http.StatusOK, "eyJhbGciOiJIUzI1NiJ9.secret-token"))
+
+		_, err := client.User.Login(context.TODO(), "admin", "s3cr3t")
+		require.NoError(t, err)
+
+		entry := decodeLogEntry(t, logBuf.String())
+		require.Equal(t, "request completed", entry["msg"])
+		require.Equal(t, "DEBUG", entry["level"])
+		require.Equal(t, http.MethodPost, entry["method"])
+		require.Equal(t, "/api/v1/user/login", entry["path"])
+		require.Equal(t, float64(http.StatusOK), entry["status"])
+		require.Equal(t, float64(1), entry["attempt"])
+		require.Equal(t, float64(len("eyJhbGciOiJIUzI1NiJ9.secret-token")), entry["response_size"])
+		require.Contains(t, entry["request_body"], "username=admin")
+		require.NotContains(t, logBuf.String(), "s3cr3t")
+		require.NotContains(t, logBuf.String(), "secret-token")
+	})
+
+	t.Run("RedactsRepositoryPassword", func(t *testing.T) {
+		logBuf.Reset()
+		httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/repository",
+			httpmock.NewStringResponder(http.StatusCreated, `{"identifier":"internal","password":"s3cr3t"}`).
+				HeaderSet(http.Header{"Content-Type": []string{"application/json"}}))
+
+		_, err := client.Repository.Create(context.TODO(), Repository{Identifier: "internal", Password: "s3cr3t"})
+		require.NoError(t, err)
+
+		entry := decodeLogEntry(t, logBuf.String())
+		require.Contains(t, entry["request_body"], `"password":"REDACTED"`)
+		require.Contains(t, entry["response_body"], `"password":"REDACTED"`)
+		require.NotContains(t, logBuf.String(), "s3cr3t")
+	})
+
+	t.Run("TruncatesBodies", func(t *testing.T) {
+		logBuf.Reset()
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+			httpmock.NewStringResponder(http.StatusOK, `{"version":"`+strings.Repeat("x", 2048)+`"}`))
+
+		_, err := client.About.Get(context.TODO())
+		require.NoError(t, err)
+
+		entry := decodeLogEntry(t, logBuf.String())
+		require.True(t, strings.HasSuffix(entry["response_body"].(string), "...(truncated)"))
+		require.Less(t, len(entry["response_body"].(string)), 1100)
+	})
+}
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Api-Key", "odt_secret")
+	header.Set("Authorization", "Bearer secret")
+	header.Set("Accept", "application/json")
+
+	redactedHeader := redactHeaders(header)
+	require.Equal(t, "REDACTED", redactedHeader.Get("X-Api-Key"))
+	require.Equal(t, "REDACTED", redactedHeader.Get("Authorization"))
+	require.Equal(t, "application/json", redactedHeader.Get("Accept"))
+	require.Equal(t, "odt_secret", header.Get("X-Api-Key"))
+}
+
+func decodeLogEntry(t *testing.T, log string) map[string]interface{} {
+	lines := strings.Split(strings.TrimSpace(log), "\n")
+	require.Len(t, lines, 1)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+
+	return entry
+}
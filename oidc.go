@@ -3,6 +3,7 @@ package dtrack
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strconv"
 
@@ -59,6 +60,13 @@ func (s OIDCService) GetAllGroups(ctx context.Context, po PageOptions) (p Page[O
 	return
 }
 
+// AllGroups returns an iterator over all items that GetAllGroups would return across all pages.
+func (s OIDCService) AllGroups(ctx context.Context, options ...IterOption) iter.Seq2[OIDCGroup, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[OIDCGroup], error) {
+		return s.GetAllGroups(ctx, po)
+	}, options...)
+}
+
 func (s OIDCService) CreateGroup(ctx context.Context, name string) (g OIDCGroup, err error) {
 	req, err := s.client.newRequest(ctx, http.MethodPut, "/api/v1/oidc/group", withBody(OIDCGroup{Name: name}))
 	if err != nil {
@@ -103,6 +111,13 @@ func (s OIDCService) GetAllTeamsOf(ctx context.Context, group OIDCGroup, po Page
 	return
 }
 
+// AllTeamsOf returns an iterator over all items that GetAllTeamsOf would return across all pages.
+func (s OIDCService) AllTeamsOf(ctx context.Context, group OIDCGroup, options ...IterOption) iter.Seq2[Team, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Team], error) {
+		return s.GetAllTeamsOf(ctx, group, po)
+	}, options...)
+}
+
 func (s OIDCService) AddTeamMapping(ctx context.Context, mapping OIDCMappingRequest) (m OIDCMapping, err error) {
 	req, err := s.client.newRequest(ctx, http.MethodPut, "/api/v1/oidc/mapping", withBody(mapping))
 	if err != nil {
@@ -3,6 +3,7 @@ package dtrack
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -32,6 +33,13 @@ func (ps PermissionService) GetAll(ctx context.Context, po PageOptions) (p Page[
 	return
 }
 
+// All returns an iterator over all items that GetAll would return across all pages.
+func (ps PermissionService) All(ctx context.Context, options ...IterOption) iter.Seq2[Permission, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Permission], error) {
+		return ps.GetAll(ctx, po)
+	}, options...)
+}
+
 func (ps PermissionService) AddPermissionToTeam(ctx context.Context, permission Permission, team uuid.UUID) (t Team, err error) {
 	req, err := ps.client.newRequest(ctx, http.MethodPost, fmt.Sprintf("/api/v1/permission/%s/team/%s", permission.Name, team.String()))
 	if err != nil {
@@ -3,6 +3,7 @@ package dtrack
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -64,6 +65,13 @@ func (ps PolicyService) GetAll(ctx context.Context, po PageOptions) (p Page[Poli
 	return
 }
 
+// All returns an iterator over all items that GetAll would return across all pages.
+func (ps PolicyService) All(ctx context.Context, options ...IterOption) iter.Seq2[Policy, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Policy], error) {
+		return ps.GetAll(ctx, po)
+	}, options...)
+}
+
 func (ps PolicyService) Create(ctx context.Context, policy Policy) (p Policy, err error) {
 	req, err := ps.client.newRequest(ctx, http.MethodPut, "/api/v1/policy", withBody(policy))
 	if err != nil {
@@ -3,6 +3,7 @@ package dtrack
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strconv"
 
@@ -42,6 +43,13 @@ func (pvs PolicyViolationService) GetAll(ctx context.Context, suppressed bool, p
 	return
 }
 
+// All returns an iterator over all items that GetAll would return across all pages.
+func (pvs PolicyViolationService) All(ctx context.Context, suppressed bool, options ...IterOption) iter.Seq2[PolicyViolation, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[PolicyViolation], error) {
+		return pvs.GetAll(ctx, suppressed, po)
+	}, options...)
+}
+
 func (pvs PolicyViolationService) GetAllForProject(ctx context.Context, projectUUID uuid.UUID, suppressed bool, po PageOptions) (p Page[PolicyViolation], err error) {
 	params := map[string]string{
 		"suppressed": strconv.FormatBool(suppressed),
@@ -61,6 +69,13 @@ func (pvs PolicyViolationService) GetAllForProject(ctx context.Context, projectU
 	return
 }
 
+// AllForProject returns an iterator over all items that GetAllForProject would return across all pages.
+func (pvs PolicyViolationService) AllForProject(ctx context.Context, projectUUID uuid.UUID, suppressed bool, options ...IterOption) iter.Seq2[PolicyViolation, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[PolicyViolation], error) {
+		return pvs.GetAllForProject(ctx, projectUUID, suppressed, po)
+	}, options...)
+}
+
 func (pvs PolicyViolationService) GetAllForComponent(ctx context.Context, componentUUID uuid.UUID, suppressed bool, po PageOptions) (p Page[PolicyViolation], err error) {
 	params := map[string]string{
 		"suppressed": strconv.FormatBool(suppressed),
@@ -79,3 +94,10 @@ func (pvs PolicyViolationService) GetAllForComponent(ctx context.Context, compon
 	p.TotalCount = res.TotalCount
 	return
 }
+
+// AllForComponent returns an iterator over all items that GetAllForComponent would return across all pages.
+func (pvs PolicyViolationService) AllForComponent(ctx context.Context, componentUUID uuid.UUID, suppressed bool, options ...IterOption) iter.Seq2[PolicyViolation, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[PolicyViolation], error) {
+		return pvs.GetAllForComponent(ctx, componentUUID, suppressed, po)
+	}, options...)
+}
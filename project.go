@@ -2,7 +2,10 @@ package dtrack
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strconv"
 
@@ -28,6 +31,7 @@ type Project struct {
 	Metrics            ProjectMetrics    `json:"metrics"`
 	ParentRef          *ParentRef        `json:"parent,omitempty"`
 	LastBOMImport      int               `json:"lastBomImport"`
+	IsLatest           bool              `json:"isLatest,omitempty"` // Whether this is the latest version of the project, since v4.12.0
 }
 
 type ParentRef struct {
@@ -48,8 +52,60 @@ func (ps ProjectService) Get(ctx context.Context, projectUUID uuid.UUID) (p Proj
 	return
 }
 
+// ProjectListOptions filters and sorts the projects returned by ProjectService.GetAllFiltered.
+type ProjectListOptions struct {
+	SearchText                string    // Text to search for in project names
+	Classifier                string    // Only include projects with this classifier, e.g. APPLICATION
+	ExcludeInactive           bool      // Exclude inactive projects
+	OnlyRoot                  bool      // Only include projects without a parent
+	NotAssignedToTeamWithUUID uuid.UUID // Exclude projects assigned to this team. Can't be combined with Classifier
+	Sort                      SortOptions
+}
+
+func (lo ProjectListOptions) params() map[string]string {
+	params := make(map[string]string)
+	if lo.SearchText != "" {
+		params["searchText"] = lo.SearchText
+	}
+	if lo.ExcludeInactive {
+		params["excludeInactive"] = "true"
+	}
+	if lo.OnlyRoot {
+		params["onlyRoot"] = "true"
+	}
+	if lo.NotAssignedToTeamWithUUID != uuid.Nil {
+		params["notAssignedToTeamWithUuid"] = lo.NotAssignedToTeamWithUUID.String()
+	}
+
+	return params
+}
+
+// GetAll fetches a page of projects.
 func (ps ProjectService) GetAll(ctx context.Context, po PageOptions) (p Page[Project], err error) {
-	req, err := ps.client.newRequest(ctx, http.MethodGet, "/api/v1/project", withPageOptions(po))
+	return ps.getAll(ctx, "Project.GetAll", po, ProjectListOptions{})
+}
+
+// GetAllFiltered fetches a page of projects, filtered and sorted on the server according to lo.
+func (ps ProjectService) GetAllFiltered(ctx context.Context, po PageOptions, lo ProjectListOptions) (p Page[Project], err error) {
+	return ps.getAll(ctx, "Project.GetAllFiltered", po, lo)
+}
+
+func (ps ProjectService) getAll(ctx context.Context, operationName string, po PageOptions, lo ProjectListOptions) (p Page[Project], err error) {
+	path := "/api/v1/project"
+	if lo.Classifier != "" {
+		if lo.NotAssignedToTeamWithUUID != uuid.Nil {
+			err = fmt.Errorf("classifier and not assigned to team filters can't be combined")
+			return
+		}
+		path = "/api/v1/project/classifier/{classifier}"
+	}
+
+	req, err := ps.client.newRequest(ctx, http.MethodGet, path,
+		withOperationName(operationName),
+		withPathParams(map[string]string{"classifier": lo.Classifier}),
+		withParams(lo.params()),
+		withSortOptions(lo.Sort),
+		withPageOptions(po))
 	if err != nil {
 		return
 	}
@@ -63,6 +119,20 @@ func (ps ProjectService) GetAll(ctx context.Context, po PageOptions) (p Page[Pro
 	return
 }
 
+// All returns an iterator over all items that GetAll would return across all pages.
+func (ps ProjectService) All(ctx context.Context, options ...IterOption) iter.Seq2[Project, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Project], error) {
+		return ps.GetAll(ctx, po)
+	}, options...)
+}
+
+// AllFiltered returns an iterator over all items that GetAllFiltered would return across all pages.
+func (ps ProjectService) AllFiltered(ctx context.Context, lo ProjectListOptions, options ...IterOption) iter.Seq2[Project, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Project], error) {
+		return ps.GetAllFiltered(ctx, po, lo)
+	}, options...)
+}
+
 func (ps ProjectService) GetProjectsForName(ctx context.Context, name string, excludeInactive, onlyRoot bool) (p []Project, err error) {
 	params := map[string]string{
 		"name":            name,
@@ -99,6 +169,14 @@ func (ps ProjectService) Patch(ctx context.Context, projectUUID uuid.UUID, proje
 	return
 }
 
+// patchActive patches the project identified by projectUUID with patch, setting its active flag to active.
+// Patch applies the active flag, as it is always included in the request body,
+// so callers must pass the current flag to preserve it.
+func (ps ProjectService) patchActive(ctx context.Context, projectUUID uuid.UUID, active bool, patch Project) (Project, error) {
+	patch.Active = active
+	return ps.Patch(ctx, projectUUID, patch)
+}
+
 func (ps ProjectService) Update(ctx context.Context, project Project) (p Project, err error) {
 	req, err := ps.client.newRequest(ctx, http.MethodPost, "/api/v1/project", withBody(project))
 	if err != nil {
@@ -157,22 +235,128 @@ func (ps ProjectService) GetAllByTag(ctx context.Context, tag string, excludeIna
 	return
 }
 
+// AllByTag returns an iterator over all items that GetAllByTag would return across all pages.
+func (ps ProjectService) AllByTag(ctx context.Context, tag string, excludeInactive, onlyRoot bool, options ...IterOption) iter.Seq2[Project, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Project], error) {
+		return ps.GetAllByTag(ctx, tag, excludeInactive, onlyRoot, po)
+	}, options...)
+}
+
+// GetChildren fetches the direct children of a project.
+// This feature is available in Dependency-Track v4.7.0 and newer.
+func (ps ProjectService) GetChildren(ctx context.Context, projectUUID uuid.UUID, po PageOptions) (p Page[Project], err error) {
+	if err = ps.client.requireFeature(ctx, FeatureProjectHierarchy); err != nil {
+		return
+	}
+
+	req, err := ps.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/project/%s/children", projectUUID), withPageOptions(po))
+	if err != nil {
+		return
+	}
+
+	res, err := ps.client.doRequest(req, &p.Items)
+	if err != nil {
+		return
+	}
+
+	p.TotalCount = res.TotalCount
+	return
+}
+
+// AllChildren returns an iterator over all items that GetChildren would return across all pages.
+func (ps ProjectService) AllChildren(ctx context.Context, projectUUID uuid.UUID, options ...IterOption) iter.Seq2[Project, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Project], error) {
+		return ps.GetChildren(ctx, projectUUID, po)
+	}, options...)
+}
+
 type ProjectCloneRequest struct {
-	ProjectUUID         uuid.UUID `json:"project"`
-	Version             string    `json:"version"`
-	IncludeAuditHistory bool      `json:"includeAuditHistory"`
-	IncludeComponents   bool      `json:"includeComponents"`
-	IncludeProperties   bool      `json:"includeProperties"`
-	IncludeServices     bool      `json:"includeServices"`
-	IncludeTags         bool      `json:"includeTags"`
+	ProjectUUID             uuid.UUID `json:"project"`
+	Version                 string    `json:"version"`
+	IncludeAuditHistory     bool      `json:"includeAuditHistory"` // Carry over analyses of findings, including their comments
+	IncludeComponents       bool      `json:"includeComponents"`
+	IncludeProperties       bool      `json:"includeProperties"`
+	IncludeServices         bool      `json:"includeServices"`
+	IncludeTags             bool      `json:"includeTags"`
+	IncludeACL              bool      `json:"includeACL,omitempty"`
+	IncludePolicyViolations bool      `json:"includePolicyViolations,omitempty"` // Carry over analyses of policy violations
+	MakeCloneLatest         bool      `json:"makeCloneLatest,omitempty"`         // Mark the clone as latest version, requires Dependency-Track v4.12.0 or newer
 }
 
-func (ps ProjectService) Clone(ctx context.Context, cloneReq ProjectCloneRequest) (err error) {
+// Clone requests a clone of a project to be created with a new version.
+// Projects are cloned asynchronously. The returned token identifies the cloning task,
+// it is empty if the server doesn't report one.
+func (ps ProjectService) Clone(ctx context.Context, cloneReq ProjectCloneRequest) (token EventToken, err error) {
+	if cloneReq.MakeCloneLatest {
+		if err = ps.client.requireFeature(ctx, FeatureProjectLatest); err != nil {
+			return
+		}
+	}
+
 	req, err := ps.client.newRequest(ctx, http.MethodPut, "/api/v1/project/clone", withBody(cloneReq))
 	if err != nil {
 		return
 	}
 
-	_, err = ps.client.doRequest(req, nil)
+	// Older versions of Dependency-Track respond with an empty body.
+	var content string
+	_, err = ps.client.doRequest(req, &content)
+	if err != nil || content == "" {
+		return
+	}
+
+	var tokenRes eventTokenResponse
+	if err = json.Unmarshal([]byte(content), &tokenRes); err != nil {
+		return
+	}
+
+	token = tokenRes.Token
+	return
+}
+
+// CloneAndWaitOptions configures CloneAndWait.
+type CloneAndWaitOptions struct {
+	Wait WaitOptions
+}
+
+// CloneAndWait clones a project using Clone, waits until cloning completed, and returns the clone.
+// A *ProcessingTimeoutError is returned when opts.Wait.Timeout is exceeded.
+//
+// Dependency-Track creates the clone before it copies components, properties and other data into it.
+// If the server returns an event token, as it does since v4.11.0, the token is polled using
+// EventService.WaitForProcessing, so that the returned clone is complete. Older servers are polled using
+// Lookup until the clone exists, in which case copying may still be in progress when the clone is returned.
+func (ps ProjectService) CloneAndWait(ctx context.Context, cloneReq ProjectCloneRequest, opts CloneAndWaitOptions) (p Project, err error) {
+	ctx, endSpan := ps.client.startSpan(ctx, "Project.CloneAndWait")
+	defer func() { endSpan(err) }()
+
+	source, err := ps.Get(ctx, cloneReq.ProjectUUID)
+	if err != nil {
+		return
+	}
+
+	token, err := ps.Clone(ctx, cloneReq)
+	if err != nil {
+		return
+	}
+
+	if token != "" {
+		if err = ps.client.Event.WaitForProcessing(ctx, token, opts.Wait); err != nil {
+			return
+		}
+
+		p, err = ps.Lookup(ctx, source.Name, cloneReq.Version)
+		return
+	}
+
+	err = waitForProcessing(ctx, string(token), opts.Wait, func(ctx context.Context) (bool, error) {
+		var lookupErr error
+		p, lookupErr = ps.Lookup(ctx, source.Name, cloneReq.Version)
+		if errors.Is(lookupErr, ErrNotFound) {
+			return true, nil
+		}
+
+		return false, lookupErr
+	})
 	return
 }
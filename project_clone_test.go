@@ -0,0 +1,98 @@
+package dtrack_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+	"github.com/futurice/dependency-track-client-go/dtracktest"
+)
+
+func TestProjectService_CloneAndWait(t *testing.T) {
+	server := dtracktest.NewServer(dtracktest.WithProcessingPolls(2))
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	source := server.AddProject(dtrack.Project{Name: "acme-app", Version: "1.0.0", Active: true, IsLatest: true, Tags: []dtrack.Tag{{Name: "prod"}}})
+	server.AddComponent(source.UUID, dtrack.Component{Name: "lodash", Version: "4.17.21"})
+	_, err = client.ProjectProperty.Create(context.TODO(), source.UUID, dtrack.ProjectProperty{Group: "ci", Name: "pipeline", Value: "main", Type: "STRING"})
+	require.NoError(t, err)
+
+	var polls int
+	clone, err := client.Project.CloneAndWait(context.TODO(), dtrack.ProjectCloneRequest{
+		ProjectUUID:         source.UUID,
+		Version:             "1.1.0",
+		IncludeAuditHistory: true,
+		IncludeComponents:   true,
+		IncludeProperties:   true,
+		IncludeTags:         true,
+		MakeCloneLatest:     true,
+	}, dtrack.CloneAndWaitOptions{
+		Wait: dtrack.WaitOptions{
+			PollInterval: time.Millisecond,
+			OnProgress: func(p dtrack.WaitProgress) {
+				polls = p.Polls
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, polls)
+	require.NotEqual(t, uuid.Nil, clone.UUID)
+	require.NotEqual(t, source.UUID, clone.UUID)
+	require.Equal(t, "acme-app", clone.Name)
+	require.Equal(t, "1.1.0", clone.Version)
+	require.Equal(t, []dtrack.Tag{{Name: "prod"}}, clone.Tags)
+	require.True(t, clone.IsLatest)
+
+	previous, err := client.Project.Get(context.TODO(), source.UUID)
+	require.NoError(t, err)
+	require.False(t, previous.IsLatest)
+
+	components, err := client.Component.GetAll(context.TODO(), clone.UUID, dtrack.PageOptions{})
+	require.NoError(t, err)
+	require.Len(t, components.Items, 1)
+
+	properties, err := client.ProjectProperty.GetAll(context.TODO(), clone.UUID, dtrack.PageOptions{})
+	require.NoError(t, err)
+	require.Len(t, properties.Items, 1)
+
+	_, err = client.Project.Clone(context.TODO(), dtrack.ProjectCloneRequest{ProjectUUID: source.UUID, Version: "1.1.0"})
+	require.ErrorIs(t, err, dtrack.ErrConflict)
+}
+
+func TestProjectService_CloneAndWait_Timeout(t *testing.T) {
+	server := dtracktest.NewServer(dtracktest.WithProcessingPolls(1000))
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	source := server.AddProject(dtrack.Project{Name: "acme-app", Version: "1.0.0", Active: true})
+
+	_, err = client.Project.CloneAndWait(context.TODO(), dtrack.ProjectCloneRequest{ProjectUUID: source.UUID, Version: "1.1.0"},
+		dtrack.CloneAndWaitOptions{Wait: dtrack.WaitOptions{PollInterval: time.Millisecond, Timeout: 50 * time.Millisecond}})
+	require.ErrorIs(t, err, dtrack.ErrProcessingTimeout)
+}
+
+func TestProjectService_Clone_MakeCloneLatestUnsupported(t *testing.T) {
+	server := dtracktest.NewServer(dtracktest.WithVersion("4.11.0"))
+	defer server.Close()
+
+	client, err := server.Client(dtrack.WithServerVersionCheck())
+	require.NoError(t, err)
+
+	source := server.AddProject(dtrack.Project{Name: "acme-app", Version: "1.0.0", Active: true})
+
+	_, err = client.Project.Clone(context.TODO(), dtrack.ProjectCloneRequest{ProjectUUID: source.UUID, Version: "1.1.0", MakeCloneLatest: true})
+	require.ErrorIs(t, err, dtrack.ErrUnsupportedByServer)
+
+	token, err := client.Project.Clone(context.TODO(), dtrack.ProjectCloneRequest{ProjectUUID: source.UUID, Version: "1.1.0"})
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+}
@@ -0,0 +1,212 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// maxEnsureAttempts is the number of times Ensure re-reads a project after a conflict
+// with a concurrent modification, before giving up.
+const maxEnsureAttempts = 3
+
+// ProjectSpec describes the desired state of a project, as converged by ProjectService.Ensure.
+// Zero values of optional fields mean that the respective aspect of the project is left as it is.
+type ProjectSpec struct {
+	Name       string
+	Version    string
+	Classifier string            // Optional
+	Tags       []string          // Optional, the exact set of tags of the project
+	Properties []ProjectProperty // Optional, properties not included are left as they are
+	ParentUUID uuid.UUID         // Optional, requires Dependency-Track v4.7.0 or newer
+	Active     *bool             // Optional, projects are created as active if not set
+}
+
+// ProjectChange describes a modification made by ProjectService.Ensure.
+type ProjectChange struct {
+	Field string // Field that was modified, e.g. "classifier", or "property:group/name" for properties
+	From  string
+	To    string
+}
+
+// EnsureResult is the outcome of ProjectService.Ensure.
+type EnsureResult struct {
+	Project Project         // Project after all changes were applied
+	Created bool            // Whether the project was created
+	Changes []ProjectChange // Modifications made to an existing project, or to the properties of a created one
+}
+
+// Changed reports whether Ensure created or modified the project.
+func (r EnsureResult) Changed() bool {
+	return r.Created || len(r.Changes) > 0
+}
+
+// Ensure converges the project identified by the name and version of spec to the state described by spec,
+// creating it if it doesn't exist yet. It is safe to call Ensure concurrently for the same project:
+// when a modification conflicts with a concurrent one, the project is re-read and Ensure starts over,
+// rather than failing or creating a duplicate.
+func (ps ProjectService) Ensure(ctx context.Context, spec ProjectSpec) (r EnsureResult, err error) {
+	if spec.Name == "" {
+		err = fmt.Errorf("project name is required")
+		return
+	}
+	if spec.ParentUUID != uuid.Nil {
+		if err = ps.client.requireFeature(ctx, FeatureProjectHierarchy); err != nil {
+			return
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = ps.ensure(ctx, spec, &r)
+		if err == nil || !errors.Is(err, ErrConflict) || attempt == maxEnsureAttempts {
+			return
+		}
+	}
+}
+
+func (ps ProjectService) ensure(ctx context.Context, spec ProjectSpec, r *EnsureResult) error {
+	project, err := ps.Lookup(ctx, spec.Name, spec.Version)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		desired := Project{
+			Name:       spec.Name,
+			Version:    spec.Version,
+			Classifier: spec.Classifier,
+			Tags:       specTags(normalizeTags(spec.Tags)),
+			Active:     spec.Active == nil || *spec.Active,
+		}
+		if spec.ParentUUID != uuid.Nil {
+			desired.ParentRef = &ParentRef{UUID: spec.ParentUUID}
+		}
+
+		if project, err = ps.Create(ctx, desired); err != nil {
+			return err
+		}
+		r.Created = true
+	case err != nil:
+		return err
+	default:
+		if project, err = ps.ensureFields(ctx, project, spec, r); err != nil {
+			return err
+		}
+	}
+	r.Project = project
+
+	return ps.ensureProperties(ctx, project.UUID, spec.Properties, r)
+}
+
+// ensureFields patches the fields of project that differ from spec.
+func (ps ProjectService) ensureFields(ctx context.Context, project Project, spec ProjectSpec, r *EnsureResult) (Project, error) {
+	var (
+		patch   Project
+		changes []ProjectChange
+	)
+	active := project.Active
+
+	if spec.Classifier != "" && spec.Classifier != project.Classifier {
+		patch.Classifier = spec.Classifier
+		changes = append(changes, ProjectChange{Field: "classifier", From: project.Classifier, To: spec.Classifier})
+	}
+
+	if len(spec.Tags) > 0 {
+		current := make([]string, 0, len(project.Tags))
+		for _, tag := range project.Tags {
+			current = append(current, tag.Name)
+		}
+		slices.Sort(current)
+		desired := normalizeTags(spec.Tags)
+
+		if !slices.Equal(current, desired) {
+			patch.Tags = specTags(desired)
+			changes = append(changes, ProjectChange{Field: "tags", From: strings.Join(current, ","), To: strings.Join(desired, ",")})
+		}
+	}
+
+	if spec.ParentUUID != uuid.Nil && (project.ParentRef == nil || project.ParentRef.UUID != spec.ParentUUID) {
+		from := ""
+		if project.ParentRef != nil {
+			from = project.ParentRef.UUID.String()
+		}
+		patch.ParentRef = &ParentRef{UUID: spec.ParentUUID}
+		changes = append(changes, ProjectChange{Field: "parent", From: from, To: spec.ParentUUID.String()})
+	}
+
+	if spec.Active != nil && *spec.Active != project.Active {
+		active = *spec.Active
+		changes = append(changes, ProjectChange{Field: "active", From: strconv.FormatBool(project.Active), To: strconv.FormatBool(*spec.Active)})
+	}
+
+	if len(changes) == 0 {
+		return project, nil
+	}
+
+	patched, err := ps.patchActive(ctx, project.UUID, active, patch)
+	if err != nil {
+		return project, err
+	}
+	r.Changes = append(r.Changes, changes...)
+
+	return patched, nil
+}
+
+// ensureProperties creates the properties of the project identified by projectUUID that don't exist yet,
+// and updates those whose value differs.
+func (ps ProjectService) ensureProperties(ctx context.Context, projectUUID uuid.UUID, properties []ProjectProperty, r *EnsureResult) error {
+	if len(properties) == 0 {
+		return nil
+	}
+
+	current := make(map[string]ProjectProperty)
+	for property, err := range ps.client.ProjectProperty.All(ctx, projectUUID) {
+		if err != nil {
+			return err
+		}
+		current[property.Group+"/"+property.Name] = property
+	}
+
+	for _, property := range properties {
+		field := "property:" + property.Group + "/" + property.Name
+
+		existing, ok := current[property.Group+"/"+property.Name]
+		if !ok {
+			if _, err := ps.client.ProjectProperty.Create(ctx, projectUUID, property); err != nil {
+				return err
+			}
+			r.Changes = append(r.Changes, ProjectChange{Field: field, To: property.Value})
+			continue
+		}
+
+		if existing.Value == property.Value {
+			continue
+		}
+		if _, err := ps.client.ProjectProperty.Update(ctx, projectUUID, property); err != nil {
+			return err
+		}
+		r.Changes = append(r.Changes, ProjectChange{Field: field, From: existing.Value, To: property.Value})
+	}
+
+	return nil
+}
+
+// normalizeTags sorts names and removes duplicates, without modifying names.
+func normalizeTags(names []string) []string {
+	return slices.Compact(slices.Sorted(slices.Values(names)))
+}
+
+func specTags(names []string) []Tag {
+	if len(names) == 0 {
+		return nil
+	}
+
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, Tag{Name: name})
+	}
+
+	return tags
+}
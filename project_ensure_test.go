@@ -0,0 +1,144 @@
+package dtrack_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+	"github.com/futurice/dependency-track-client-go/dtracktest"
+)
+
+func TestProjectService_Ensure(t *testing.T) {
+	server := dtracktest.NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	parent := server.AddProject(dtrack.Project{Name: "product", Active: true})
+
+	spec := dtrack.ProjectSpec{
+		Name:       "acme-app",
+		Version:    "1.0.0",
+		Classifier: "APPLICATION",
+		Tags:       []string{"team-a", "prod", "team-a"},
+		Properties: []dtrack.ProjectProperty{{Group: "ci", Name: "pipeline", Value: "main", Type: "STRING"}},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		result, err := client.Project.Ensure(context.TODO(), spec)
+		require.NoError(t, err)
+		require.True(t, result.Created)
+		require.True(t, result.Changed())
+		require.Equal(t, []dtrack.ProjectChange{{Field: "property:ci/pipeline", To: "main"}}, result.Changes)
+		require.Equal(t, "APPLICATION", result.Project.Classifier)
+		require.Equal(t, []dtrack.Tag{{Name: "prod"}, {Name: "team-a"}}, result.Project.Tags)
+		require.True(t, result.Project.Active)
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		result, err := client.Project.Ensure(context.TODO(), spec)
+		require.NoError(t, err)
+		require.False(t, result.Changed())
+	})
+
+	t.Run("Converge", func(t *testing.T) {
+		inactive := false
+		spec := spec
+		spec.Classifier = "LIBRARY"
+		spec.Tags = []string{"team-b"}
+		spec.Properties = []dtrack.ProjectProperty{
+			{Group: "ci", Name: "pipeline", Value: "release", Type: "STRING"},
+			{Group: "ci", Name: "owner", Value: "platform", Type: "STRING"},
+		}
+		spec.ParentUUID = parent.UUID
+		spec.Active = &inactive
+
+		result, err := client.Project.Ensure(context.TODO(), spec)
+		require.NoError(t, err)
+		require.False(t, result.Created)
+		require.Equal(t, []dtrack.ProjectChange{
+			{Field: "classifier", From: "APPLICATION", To: "LIBRARY"},
+			{Field: "tags", From: "prod,team-a", To: "team-b"},
+			{Field: "parent", From: "", To: parent.UUID.String()},
+			{Field: "active", From: "true", To: "false"},
+			{Field: "property:ci/pipeline", From: "main", To: "release"},
+			{Field: "property:ci/owner", To: "platform"},
+		}, result.Changes)
+		require.Equal(t, "LIBRARY", result.Project.Classifier)
+		require.Equal(t, parent.UUID, result.Project.ParentRef.UUID)
+		require.False(t, result.Project.Active)
+
+		project, err := client.Project.Get(context.TODO(), result.Project.UUID)
+		require.NoError(t, err)
+		require.Equal(t, []dtrack.Tag{{Name: "team-b"}}, project.Tags)
+
+		var properties []dtrack.ProjectProperty
+		for property, err := range client.ProjectProperty.All(context.TODO(), project.UUID) {
+			require.NoError(t, err)
+			properties = append(properties, property)
+		}
+		require.Len(t, properties, 2)
+		require.Equal(t, "release", properties[0].Value)
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		server.InjectFailure(dtracktest.Failure{
+			Method:     http.MethodPut,
+			PathPrefix: "/api/v1/project/",
+			StatusCode: http.StatusConflict,
+			Times:      1,
+		})
+
+		spec := spec
+		spec.Properties = []dtrack.ProjectProperty{{Group: "ci", Name: "runner", Value: "linux", Type: "STRING"}}
+
+		result, err := client.Project.Ensure(context.TODO(), spec)
+		require.NoError(t, err)
+		require.Contains(t, result.Changes, dtrack.ProjectChange{Field: "property:ci/runner", To: "linux"})
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		_, err := client.Project.Ensure(context.TODO(), dtrack.ProjectSpec{Version: "1.0.0"})
+		require.Error(t, err)
+	})
+}
+
+func TestProjectService_Ensure_Concurrent(t *testing.T) {
+	server := dtracktest.NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	spec := dtrack.ProjectSpec{Name: "acme-app", Version: "1.0.0", Tags: []string{"prod"}}
+
+	const pipelines = 8
+	results := make([]dtrack.EnsureResult, pipelines)
+	errs := make([]error, pipelines)
+
+	var wg sync.WaitGroup
+	for i := 0; i < pipelines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = client.Project.Ensure(context.TODO(), spec)
+		}()
+	}
+	wg.Wait()
+
+	created := 0
+	for i := 0; i < pipelines; i++ {
+		require.NoError(t, errs[i])
+		require.Equal(t, results[0].Project.UUID, results[i].Project.UUID)
+		if results[i].Created {
+			created++
+		}
+	}
+	require.Equal(t, 1, created)
+	require.Len(t, server.Projects(), 1)
+}
@@ -3,6 +3,7 @@ package dtrack
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -35,6 +36,13 @@ func (ps ProjectPropertyService) GetAll(ctx context.Context, projectUUID uuid.UU
 	return
 }
 
+// All returns an iterator over all items that GetAll would return across all pages.
+func (ps ProjectPropertyService) All(ctx context.Context, projectUUID uuid.UUID, options ...IterOption) iter.Seq2[ProjectProperty, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[ProjectProperty], error) {
+		return ps.GetAll(ctx, projectUUID, po)
+	}, options...)
+}
+
 func (ps ProjectPropertyService) Create(ctx context.Context, projectUUID uuid.UUID, property ProjectProperty) (p ProjectProperty, err error) {
 	req, err := ps.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("/api/v1/project/%s/property", projectUUID), withBody(property))
 	if err != nil {
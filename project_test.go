@@ -0,0 +1,153 @@
+package dtrack
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectService_GetAllFiltered(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	var (
+		query     url.Values
+		operation string
+	)
+	responder := func(req *http.Request) (*http.Response, error) {
+		query = req.URL.Query()
+		operation = requestOperation(req).String()
+		res := httpmock.NewStringResponse(http.StatusOK, `[{"name":"acme-app"}]`)
+		res.Header.Set("X-Total-Count", "1")
+		return res, nil
+	}
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project", responder)
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/classifier/LIBRARY", responder)
+
+	_, err = client.Project.GetAll(context.TODO(), PageOptions{PageNumber: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, url.Values{"pageNumber": {"1"}, "pageSize": {"10"}}, query)
+	require.Equal(t, "dtrack.Project.GetAll", operation)
+
+	teamUUID := uuid.MustParse("4cb0c8ff-0a5c-4a2b-9b4c-1e4e1d1e8b5a")
+	page, err := client.Project.GetAllFiltered(context.TODO(), PageOptions{PageNumber: 2, PageSize: 10}, ProjectListOptions{
+		SearchText:                "acme",
+		ExcludeInactive:           true,
+		OnlyRoot:                  true,
+		NotAssignedToTeamWithUUID: teamUUID,
+		Sort:                      SortOptions{Name: "name", Order: SortDescending},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, page.TotalCount)
+	require.Equal(t, url.Values{
+		"searchText":                {"acme"},
+		"excludeInactive":           {"true"},
+		"onlyRoot":                  {"true"},
+		"notAssignedToTeamWithUuid": {teamUUID.String()},
+		"sortName":                  {"name"},
+		"sortOrder":                 {"desc"},
+		"pageNumber":                {"2"},
+		"pageSize":                  {"10"},
+	}, query)
+	require.Equal(t, "dtrack.Project.GetAllFiltered", operation)
+
+	var projects []Project
+	for project, err := range client.Project.AllFiltered(context.TODO(), ProjectListOptions{Classifier: "LIBRARY", ExcludeInactive: true}, WithPageSize(100)) {
+		require.NoError(t, err)
+		projects = append(projects, project)
+	}
+	require.Len(t, projects, 1)
+	require.Equal(t, url.Values{"excludeInactive": {"true"}, "pageNumber": {"1"}, "pageSize": {"100"}}, query)
+
+	calls := httpmock.GetTotalCallCount()
+	_, err = client.Project.GetAllFiltered(context.TODO(), PageOptions{}, ProjectListOptions{Classifier: "LIBRARY", NotAssignedToTeamWithUUID: teamUUID})
+	require.EqualError(t, err, "classifier and not assigned to team filters can't be combined")
+	require.Equal(t, calls, httpmock.GetTotalCallCount())
+}
+
+func TestComponentService_GetAllFiltered(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	projectUUID := uuid.MustParse("6fb1820f-5280-4577-ac51-40124aabe307")
+
+	var query url.Values
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/component/project/"+projectUUID.String(),
+		func(req *http.Request) (*http.Response, error) {
+			query = req.URL.Query()
+			return httpmock.NewStringResponse(http.StatusOK, `[]`), nil
+		})
+
+	_, err = client.Component.GetAllFiltered(context.TODO(), projectUUID, PageOptions{}, ComponentListOptions{
+		SearchText:   "log4j",
+		OnlyOutdated: true,
+		OnlyDirect:   true,
+		Sort:         SortOptions{Name: "version"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"searchText":   {"log4j"},
+		"onlyOutdated": {"true"},
+		"onlyDirect":   {"true"},
+		"sortName":     {"version"},
+	}, query)
+}
+
+func TestProjectService_Clone(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/project/clone",
+		httpmock.NewStringResponder(http.StatusOK, `{"token":"foo"}`).
+			Then(httpmock.NewStringResponder(http.StatusOK, "")))
+
+	cloneReq := ProjectCloneRequest{ProjectUUID: uuid.New(), Version: "1.1.0"}
+
+	token, err := client.Project.Clone(context.TODO(), cloneReq)
+	require.NoError(t, err)
+	require.Equal(t, EventToken("foo"), token)
+
+	// Older servers don't report a token.
+	token, err = client.Project.Clone(context.TODO(), cloneReq)
+	require.NoError(t, err)
+	require.Empty(t, token)
+}
+
+func TestProjectService_CloneAndWait_WithoutEventToken(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	sourceUUID := uuid.MustParse("6fb1820f-5280-4577-ac51-40124aabe307")
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/"+sourceUUID.String(),
+		httpmock.NewStringResponder(http.StatusOK, `{"uuid":"`+sourceUUID.String()+`","name":"acme-app","version":"1.0.0"}`))
+	httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/project/clone",
+		httpmock.NewStringResponder(http.StatusOK, ""))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/lookup?name=acme-app&version=1.1.0",
+		httpmock.NewStringResponder(http.StatusNotFound, "The project could not be found.").
+			Then(httpmock.NewStringResponder(http.StatusOK, `{"uuid":"4cb0c8ff-0a5c-4a2b-9b4c-1e4e1d1e8b5a","name":"acme-app","version":"1.1.0"}`)))
+
+	clone, err := client.Project.CloneAndWait(context.TODO(), ProjectCloneRequest{ProjectUUID: sourceUUID, Version: "1.1.0"},
+		CloneAndWaitOptions{Wait: WaitOptions{PollInterval: time.Millisecond}})
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0", clone.Version)
+	require.Equal(t, 2, httpmock.GetCallCountInfo()["GET http://localhost/api/v1/project/lookup?name=acme-app&version=1.1.0"])
+	require.Zero(t, httpmock.GetCallCountInfo()["GET =~^http://localhost/api/v1/event/token/"])
+}
@@ -0,0 +1,213 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrHierarchyCycle is returned when a project hierarchy contains, or would contain, a cycle.
+// Use errors.As with *HierarchyCycleError to learn which projects form the cycle.
+var ErrHierarchyCycle = errors.New("project hierarchy cycle")
+
+// HierarchyCycleError is returned when a project hierarchy contains, or would contain, a cycle.
+type HierarchyCycleError struct {
+	Path []uuid.UUID // UUIDs of the projects forming the cycle, from child to parent, starting and ending with the same project
+}
+
+func (e HierarchyCycleError) Error() string {
+	path := make([]string, 0, len(e.Path))
+	for _, projectUUID := range e.Path {
+		path = append(path, projectUUID.String())
+	}
+
+	return fmt.Sprintf("project hierarchy contains a cycle: %s", strings.Join(path, " -> "))
+}
+
+func (e HierarchyCycleError) Is(target error) bool {
+	return target == ErrHierarchyCycle
+}
+
+// SkipChildren is used as a return value from the function passed to ProjectTree.Walk
+// to indicate that the children of the current project are to be skipped.
+// It is not returned as an error by any function.
+var SkipChildren = errors.New("skip children")
+
+// ProjectTree is a project along with all of its descendants.
+type ProjectTree struct {
+	Project  Project
+	Children []*ProjectTree
+}
+
+// GetTree resolves the hierarchy of projects below the project identified by rootUUID,
+// using GetChildren. Inactive projects are included.
+// This feature is available in Dependency-Track v4.7.0 and newer.
+func (ps ProjectService) GetTree(ctx context.Context, rootUUID uuid.UUID) (t *ProjectTree, err error) {
+	root, err := ps.Get(ctx, rootUUID)
+	if err != nil {
+		return
+	}
+
+	t = &ProjectTree{Project: root}
+	if err = ps.resolveChildren(ctx, t, []uuid.UUID{root.UUID}); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// resolveChildren resolves the descendants of node. ancestors are the UUIDs of all projects
+// from the root down to node, and are used to detect cycles.
+func (ps ProjectService) resolveChildren(ctx context.Context, node *ProjectTree, ancestors []uuid.UUID) error {
+	for child, err := range ps.AllChildren(ctx, node.Project.UUID) {
+		if err != nil {
+			return err
+		}
+
+		if i := slices.Index(ancestors, child.UUID); i >= 0 {
+			return &HierarchyCycleError{Path: append(slices.Clone(ancestors[i:]), child.UUID)}
+		}
+
+		childNode := &ProjectTree{Project: child}
+		node.Children = append(node.Children, childNode)
+	}
+
+	for _, childNode := range node.Children {
+		if err := ps.resolveChildren(ctx, childNode, append(ancestors, childNode.Project.UUID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Walk visits the project of t and all of its descendants depth-first, parents before their children.
+// depth is 0 for the project of t, 1 for its children, and so on. If fn returns SkipChildren,
+// the children of the current project are not visited. Any other error stops the walk and is returned.
+func (t *ProjectTree) Walk(fn func(node *ProjectTree, depth int) error) error {
+	return t.walk(fn, 0)
+}
+
+func (t *ProjectTree) walk(fn func(node *ProjectTree, depth int) error, depth int) error {
+	if err := fn(t, depth); err != nil {
+		if errors.Is(err, SkipChildren) {
+			return nil
+		}
+		return err
+	}
+
+	for _, child := range t.Children {
+		if err := child.walk(fn, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Find returns the node of the project identified by projectUUID, or nil if it is not part of t.
+func (t *ProjectTree) Find(projectUUID uuid.UUID) *ProjectTree {
+	if t.Project.UUID == projectUUID {
+		return t
+	}
+
+	for _, child := range t.Children {
+		if node := child.Find(projectUUID); node != nil {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// AggregateMetrics sums up the metrics of the project of t and all of its descendants,
+// as they were included in the project representations when the tree was resolved.
+// FirstOccurrence and LastOccurrence are the earliest and latest occurrence across all projects.
+func (t *ProjectTree) AggregateMetrics() (m ProjectMetrics) {
+	_ = t.Walk(func(node *ProjectTree, _ int) error {
+		m = addProjectMetrics(m, node.Project.Metrics)
+		return nil
+	})
+
+	return
+}
+
+func addProjectMetrics(a, b ProjectMetrics) ProjectMetrics {
+	sum := ProjectMetrics{
+		FirstOccurrence:                      a.FirstOccurrence,
+		LastOccurrence:                       max(a.LastOccurrence, b.LastOccurrence),
+		InheritedRiskScore:                   a.InheritedRiskScore + b.InheritedRiskScore,
+		Vulnerabilities:                      a.Vulnerabilities + b.Vulnerabilities,
+		VulnerableComponents:                 a.VulnerableComponents + b.VulnerableComponents,
+		Components:                           a.Components + b.Components,
+		Suppressed:                           a.Suppressed + b.Suppressed,
+		Critical:                             a.Critical + b.Critical,
+		High:                                 a.High + b.High,
+		Medium:                               a.Medium + b.Medium,
+		Low:                                  a.Low + b.Low,
+		Unassigned:                           a.Unassigned + b.Unassigned,
+		FindingsTotal:                        a.FindingsTotal + b.FindingsTotal,
+		FindingsAudited:                      a.FindingsAudited + b.FindingsAudited,
+		FindingsUnaudited:                    a.FindingsUnaudited + b.FindingsUnaudited,
+		PolicyViolationsTotal:                a.PolicyViolationsTotal + b.PolicyViolationsTotal,
+		PolicyViolationsFail:                 a.PolicyViolationsFail + b.PolicyViolationsFail,
+		PolicyViolationsWarn:                 a.PolicyViolationsWarn + b.PolicyViolationsWarn,
+		PolicyViolationsInfo:                 a.PolicyViolationsInfo + b.PolicyViolationsInfo,
+		PolicyViolationsAudited:              a.PolicyViolationsAudited + b.PolicyViolationsAudited,
+		PolicyViolationsUnaudited:            a.PolicyViolationsUnaudited + b.PolicyViolationsUnaudited,
+		PolicyViolationsSecurityTotal:        a.PolicyViolationsSecurityTotal + b.PolicyViolationsSecurityTotal,
+		PolicyViolationsSecurityAudited:      a.PolicyViolationsSecurityAudited + b.PolicyViolationsSecurityAudited,
+		PolicyViolationsSecurityUnaudited:    a.PolicyViolationsSecurityUnaudited + b.PolicyViolationsSecurityUnaudited,
+		PolicyViolationsLicenseTotal:         a.PolicyViolationsLicenseTotal + b.PolicyViolationsLicenseTotal,
+		PolicyViolationsLicenseAudited:       a.PolicyViolationsLicenseAudited + b.PolicyViolationsLicenseAudited,
+		PolicyViolationsLicenseUnaudited:     a.PolicyViolationsLicenseUnaudited + b.PolicyViolationsLicenseUnaudited,
+		PolicyViolationsOperationalTotal:     a.PolicyViolationsOperationalTotal + b.PolicyViolationsOperationalTotal,
+		PolicyViolationsOperationalAudited:   a.PolicyViolationsOperationalAudited + b.PolicyViolationsOperationalAudited,
+		PolicyViolationsOperationalUnaudited: a.PolicyViolationsOperationalUnaudited + b.PolicyViolationsOperationalUnaudited,
+	}
+	if sum.FirstOccurrence == 0 || (b.FirstOccurrence != 0 && b.FirstOccurrence < sum.FirstOccurrence) {
+		sum.FirstOccurrence = b.FirstOccurrence
+	}
+
+	return sum
+}
+
+// SetParent moves the project identified by projectUUID below the project identified by parentUUID, using Patch.
+// The ancestors of the new parent are resolved beforehand, and a HierarchyCycleError is returned instead
+// if the project would become its own ancestor.
+// This feature is available in Dependency-Track v4.7.0 and newer.
+func (ps ProjectService) SetParent(ctx context.Context, projectUUID, parentUUID uuid.UUID) (p Project, err error) {
+	if err = ps.client.requireFeature(ctx, FeatureProjectHierarchy); err != nil {
+		return
+	}
+
+	path := []uuid.UUID{projectUUID}
+	for ancestorUUID := &parentUUID; ancestorUUID != nil; {
+		if i := slices.Index(path, *ancestorUUID); i >= 0 {
+			err = &HierarchyCycleError{Path: append(path[i:], *ancestorUUID)}
+			return
+		}
+		path = append(path, *ancestorUUID)
+
+		var ancestor Project
+		if ancestor, err = ps.Get(ctx, *ancestorUUID); err != nil {
+			return
+		}
+
+		ancestorUUID = nil
+		if ancestor.ParentRef != nil {
+			ancestorUUID = &ancestor.ParentRef.UUID
+		}
+	}
+
+	project, err := ps.Get(ctx, projectUUID)
+	if err != nil {
+		return
+	}
+
+	return ps.patchActive(ctx, projectUUID, project.Active, Project{ParentRef: &ParentRef{UUID: parentUUID}})
+}
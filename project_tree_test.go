@@ -0,0 +1,132 @@
+package dtrack_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+	"github.com/futurice/dependency-track-client-go/dtracktest"
+)
+
+func TestProjectService_GetTree(t *testing.T) {
+	server := dtracktest.NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	root := server.AddProject(dtrack.Project{Name: "product", Active: true, Metrics: dtrack.ProjectMetrics{Critical: 1, Components: 10, FirstOccurrence: 300, LastOccurrence: 500}})
+	backend := server.AddProject(dtrack.Project{Name: "backend", Active: true, ParentRef: &dtrack.ParentRef{UUID: root.UUID}, Metrics: dtrack.ProjectMetrics{High: 2, Components: 20, FirstOccurrence: 100, LastOccurrence: 400}})
+	frontend := server.AddProject(dtrack.Project{Name: "frontend", Active: false, ParentRef: &dtrack.ParentRef{UUID: root.UUID}, Metrics: dtrack.ProjectMetrics{Components: 30}})
+	database := server.AddProject(dtrack.Project{Name: "database", Active: true, ParentRef: &dtrack.ParentRef{UUID: backend.UUID}, Metrics: dtrack.ProjectMetrics{High: 1, Components: 5, FirstOccurrence: 200, LastOccurrence: 600}})
+	server.AddProject(dtrack.Project{Name: "unrelated", Active: true})
+
+	tree, err := client.Project.GetTree(context.TODO(), root.UUID)
+	require.NoError(t, err)
+	require.Equal(t, root.UUID, tree.Project.UUID)
+	require.Len(t, tree.Children, 2)
+
+	t.Run("Walk", func(t *testing.T) {
+		var visited []string
+		var depths []int
+		err := tree.Walk(func(node *dtrack.ProjectTree, depth int) error {
+			visited = append(visited, node.Project.Name)
+			depths = append(depths, depth)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"product", "backend", "database", "frontend"}, visited)
+		require.Equal(t, []int{0, 1, 2, 1}, depths)
+
+		visited = nil
+		err = tree.Walk(func(node *dtrack.ProjectTree, _ int) error {
+			visited = append(visited, node.Project.Name)
+			if node.Project.UUID == backend.UUID {
+				return dtrack.SkipChildren
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"product", "backend", "frontend"}, visited)
+
+		errStop := errors.New("stop")
+		err = tree.Walk(func(node *dtrack.ProjectTree, _ int) error {
+			return errStop
+		})
+		require.ErrorIs(t, err, errStop)
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		node := tree.Find(database.UUID)
+		require.NotNil(t, node)
+		require.Equal(t, "database", node.Project.Name)
+
+		node = tree.Find(frontend.UUID)
+		require.NotNil(t, node)
+		require.False(t, node.Project.Active)
+
+		require.Nil(t, tree.Find(uuid.New()))
+	})
+
+	t.Run("AggregateMetrics", func(t *testing.T) {
+		metrics := tree.AggregateMetrics()
+		require.Equal(t, 1, metrics.Critical)
+		require.Equal(t, 3, metrics.High)
+		require.Equal(t, 65, metrics.Components)
+		require.Equal(t, 100, metrics.FirstOccurrence)
+		require.Equal(t, 600, metrics.LastOccurrence)
+
+		backendMetrics := tree.Find(backend.UUID).AggregateMetrics()
+		require.Equal(t, 25, backendMetrics.Components)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := client.Project.GetTree(context.TODO(), uuid.New())
+		require.ErrorIs(t, err, dtrack.ErrNotFound)
+	})
+}
+
+func TestProjectService_SetParent(t *testing.T) {
+	server := dtracktest.NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	root := server.AddProject(dtrack.Project{Name: "product", Active: true})
+	child := server.AddProject(dtrack.Project{Name: "backend", Active: true, ParentRef: &dtrack.ParentRef{UUID: root.UUID}})
+	grandchild := server.AddProject(dtrack.Project{Name: "database", Active: true, ParentRef: &dtrack.ParentRef{UUID: child.UUID}})
+	orphan := server.AddProject(dtrack.Project{Name: "frontend", Active: true})
+
+	t.Run("Success", func(t *testing.T) {
+		project, err := client.Project.SetParent(context.TODO(), orphan.UUID, child.UUID)
+		require.NoError(t, err)
+		require.True(t, project.Active)
+		require.NotNil(t, project.ParentRef)
+		require.Equal(t, child.UUID, project.ParentRef.UUID)
+
+		tree, err := client.Project.GetTree(context.TODO(), root.UUID)
+		require.NoError(t, err)
+		require.NotNil(t, tree.Find(orphan.UUID))
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		_, err := client.Project.SetParent(context.TODO(), root.UUID, grandchild.UUID)
+		require.ErrorIs(t, err, dtrack.ErrHierarchyCycle)
+
+		var cycleErr *dtrack.HierarchyCycleError
+		require.ErrorAs(t, err, &cycleErr)
+		require.Equal(t, []uuid.UUID{root.UUID, grandchild.UUID, child.UUID, root.UUID}, cycleErr.Path)
+
+		_, err = client.Project.SetParent(context.TODO(), root.UUID, root.UUID)
+		require.ErrorIs(t, err, dtrack.ErrHierarchyCycle)
+
+		project, err := client.Project.Get(context.TODO(), root.UUID)
+		require.NoError(t, err)
+		require.Nil(t, project.ParentRef)
+	})
+}
@@ -0,0 +1,226 @@
+package dtrack
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WithRateLimit limits the rate of requests sent by the client to requestsPerSecond,
+// allowing bursts of up to burst requests. The limit is shared by all services of the client.
+// Requests exceeding the limit block until they are allowed to proceed, or their context is cancelled.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) error {
+		if requestsPerSecond <= 0 {
+			return fmt.Errorf("requests per second must be greater than zero")
+		}
+		if burst < 1 {
+			return fmt.Errorf("burst must be at least 1")
+		}
+
+		if c.throttle == nil {
+			c.throttle = &throttleTransport{}
+		}
+		c.throttle.limiter = newTokenBucket(requestsPerSecond, burst)
+
+		return nil
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of requests that are in flight at the same time.
+// The limit is shared by all services of the client. A request occupies its slot until its
+// response body has been closed.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) error {
+		if n < 1 {
+			return fmt.Errorf("max concurrent requests must be at least 1")
+		}
+
+		if c.throttle == nil {
+			c.throttle = &throttleTransport{}
+		}
+		c.throttle.slots = make(chan struct{}, n)
+
+		return nil
+	}
+}
+
+// ThrottleStats holds counters about requests that were delayed
+// by WithRateLimit or WithMaxConcurrentRequests.
+type ThrottleStats struct {
+	Requests        int64         // Number of requests that passed the throttle
+	Delayed         int64         // Number of requests that had to wait
+	RateLimitWait   time.Duration // Total time spent waiting for the rate limit
+	ConcurrencyWait time.Duration // Total time spent waiting for a free request slot
+}
+
+// ThrottleStats returns a snapshot of the client's throttling counters.
+// All counters are zero when neither WithRateLimit nor WithMaxConcurrentRequests are used.
+func (c Client) ThrottleStats() ThrottleStats {
+	if c.throttle == nil {
+		return ThrottleStats{}
+	}
+
+	return ThrottleStats{
+		Requests:        atomic.LoadInt64(&c.throttle.requests),
+		Delayed:         atomic.LoadInt64(&c.throttle.delayed),
+		RateLimitWait:   time.Duration(atomic.LoadInt64(&c.throttle.rateLimitWait)),
+		ConcurrencyWait: time.Duration(atomic.LoadInt64(&c.throttle.concurrencyWait)),
+	}
+}
+
+type throttleTransport struct {
+	limiter   *tokenBucket
+	slots     chan struct{}
+	transport http.RoundTripper
+
+	requests        int64
+	delayed         int64
+	rateLimitWait   int64
+	concurrencyWait int64
+}
+
+// install wraps the transport of httpClient.
+func (t *throttleTransport) install(httpClient *http.Client) {
+	t.transport = httpClient.Transport
+	if t.transport == nil {
+		t.transport = http.DefaultTransport
+	}
+
+	httpClient.Transport = t
+}
+
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	delayed := false
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		default:
+			delayed = true
+			start := time.Now()
+			select {
+			case t.slots <- struct{}{}:
+			case <-ctx.Done():
+				closeRequestBody(req)
+				return nil, ctx.Err()
+			}
+			atomic.AddInt64(&t.concurrencyWait, int64(time.Since(start)))
+		}
+	}
+
+	if t.limiter != nil {
+		wait, err := t.limiter.wait(ctx)
+		if err != nil {
+			t.release()
+			closeRequestBody(req)
+			return nil, err
+		}
+		if wait > 0 {
+			delayed = true
+			atomic.AddInt64(&t.rateLimitWait, int64(wait))
+		}
+	}
+
+	atomic.AddInt64(&t.requests, 1)
+	if delayed {
+		atomic.AddInt64(&t.delayed, 1)
+	}
+
+	res, err := t.transport.RoundTrip(req)
+	if err != nil {
+		t.release()
+		return nil, err
+	}
+
+	if t.slots != nil {
+		res.Body = &releasingBody{ReadCloser: res.Body, release: t.release}
+	}
+
+	return res, nil
+}
+
+func (t *throttleTransport) release() {
+	if t.slots != nil {
+		<-t.slots
+	}
+}
+
+// releasingBody frees a request slot once the response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// tokenBucket is a simple token bucket rate limiter.
+type tokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64 // Tokens added per second
+	burst  float64 // Maximum number of tokens
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available, or ctx is cancelled.
+// It returns the time spent waiting.
+func (tb *tokenBucket) wait(ctx context.Context) (time.Duration, error) {
+	tb.mutex.Lock()
+	now := time.Now()
+	tb.tokens += now.Sub(tb.last).Seconds() * tb.rate
+	if tb.tokens > tb.burst {
+		tb.tokens = tb.burst
+	}
+	tb.last = now
+
+	// Reserve a token, even if that means going into debt.
+	tb.tokens--
+	if tb.tokens >= 0 {
+		tb.mutex.Unlock()
+		return 0, nil
+	}
+	delay := time.Duration(-tb.tokens / tb.rate * float64(time.Second))
+	tb.mutex.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return delay, nil
+	case <-ctx.Done():
+		// Hand back the reserved token.
+		tb.mutex.Lock()
+		tb.tokens++
+		tb.mutex.Unlock()
+		return 0, ctx.Err()
+	}
+}
+
+// closeRequestBody closes the body of a request that won't be sent,
+// as required of RoundTrippers. This terminates the producers of streamed bodies.
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
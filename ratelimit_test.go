@@ -0,0 +1,96 @@
+package dtrack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"version":"4.8.0"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithRateLimit(50, 1))
+	require.NoError(t, err)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err = client.About.Get(context.TODO())
+		require.NoError(t, err)
+	}
+	require.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+
+	stats := client.ThrottleStats()
+	require.Equal(t, int64(5), stats.Requests)
+	require.Equal(t, int64(4), stats.Delayed)
+	require.Greater(t, stats.RateLimitWait, time.Duration(0))
+	require.Zero(t, stats.ConcurrencyWait)
+}
+
+func TestWithRateLimit_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithRateLimit(0.1, 1))
+	require.NoError(t, err)
+
+	_, err = client.About.Get(context.TODO())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = client.About.Get(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWithMaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithMaxConcurrentRequests(2))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.About.Get(context.TODO())
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+
+	stats := client.ThrottleStats()
+	require.Equal(t, int64(8), stats.Requests)
+	require.Greater(t, stats.Delayed, int64(0))
+	require.Greater(t, stats.ConcurrencyWait, time.Duration(0))
+}
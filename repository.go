@@ -3,6 +3,7 @@ package dtrack
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -73,6 +74,13 @@ func (rs RepositoryService) GetAll(ctx context.Context, po PageOptions) (p Page[
 	return
 }
 
+// All returns an iterator over all items that GetAll would return across all pages.
+func (rs RepositoryService) All(ctx context.Context, options ...IterOption) iter.Seq2[Repository, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Repository], error) {
+		return rs.GetAll(ctx, po)
+	}, options...)
+}
+
 func (rs RepositoryService) GetByType(ctx context.Context, repoType RepositoryType, po PageOptions) (p Page[Repository], err error) {
 	req, err := rs.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/repository/%s", repoType), withPageOptions(po))
 	if err != nil {
@@ -88,6 +96,13 @@ func (rs RepositoryService) GetByType(ctx context.Context, repoType RepositoryTy
 	return
 }
 
+// AllByType returns an iterator over all items that GetByType would return across all pages.
+func (rs RepositoryService) AllByType(ctx context.Context, repoType RepositoryType, options ...IterOption) iter.Seq2[Repository, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Repository], error) {
+		return rs.GetByType(ctx, repoType, po)
+	}, options...)
+}
+
 func (rs RepositoryService) Create(ctx context.Context, repo Repository) (r Repository, err error) {
 	req, err := rs.client.newRequest(ctx, http.MethodPut, "/api/v1/repository", withBody(repo))
 	if err != nil {
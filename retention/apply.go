@@ -0,0 +1,80 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+)
+
+// AuditEntry records a change made by Apply.
+type AuditEntry struct {
+	Time     time.Time `json:"time"`
+	Decision Decision  `json:"decision"`
+	Error    string    `json:"error,omitempty"` // Empty if the change was made successfully
+}
+
+// AuditLog lists the changes made by Apply, in the order they were made.
+type AuditLog []AuditEntry
+
+// Apply makes the changes of plan. Versions are deactivated using ProjectService.Patch,
+// and deleted using ProjectService.Delete.
+//
+// A failed change doesn't prevent the remaining changes from being made; errors of all failed
+// changes are joined and returned. Every change is recorded in the audit log, and additionally
+// logged using logger, if it is not nil.
+func Apply(ctx context.Context, client *dtrack.Client, plan Plan, logger *slog.Logger) (auditLog AuditLog, err error) {
+	var errs []error
+	for _, d := range plan.Changes() {
+		if err = ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		switch d.Action {
+		case ActionDeactivate:
+			_, err = client.Project.Patch(ctx, d.ProjectUUID, dtrack.Project{Active: false})
+		case ActionDelete:
+			err = client.Project.Delete(ctx, d.ProjectUUID)
+		case ActionKeep:
+			continue
+		default:
+			err = fmt.Errorf("unknown action %q", d.Action)
+		}
+
+		entry := AuditEntry{Time: time.Now().UTC(), Decision: d}
+		if err != nil {
+			err = fmt.Errorf("failed to %s %s@%s: %w", d.Action, d.Name, d.Version, err)
+			entry.Error = err.Error()
+			errs = append(errs, err)
+		}
+		auditLog = append(auditLog, entry)
+
+		if logger != nil {
+			logChange(ctx, logger, entry)
+		}
+	}
+
+	err = errors.Join(errs...)
+	return
+}
+
+func logChange(ctx context.Context, logger *slog.Logger, entry AuditEntry) {
+	attrs := []slog.Attr{
+		slog.String("action", string(entry.Decision.Action)),
+		slog.String("project_uuid", entry.Decision.ProjectUUID.String()),
+		slog.String("project_name", entry.Decision.Name),
+		slog.String("project_version", entry.Decision.Version),
+		slog.String("reason", entry.Decision.Reason),
+	}
+
+	if entry.Error != "" {
+		logger.LogAttrs(ctx, slog.LevelError, "retention change failed", append(attrs, slog.String("error", entry.Error))...)
+		return
+	}
+
+	logger.LogAttrs(ctx, slog.LevelInfo, "retention change applied", attrs...)
+}
@@ -0,0 +1,18 @@
+// Package retention cleans up stale versions of Dependency-Track projects, e.g. the versions
+// uploaded by every build of a CI pipeline.
+//
+// Versions of a project are grouped by project name and evaluated against a Policy, which determines
+// which versions are kept, deactivated or deleted. The age of a version is the time since its last BOM import.
+// Evaluating a policy results in a Plan, which serves as dry run and can be reviewed before it is applied:
+//
+//	plan, err := retention.NewPlan(ctx, client, policy, time.Now(), "acme-app")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Print(plan)
+//
+//	auditLog, err := retention.Apply(ctx, client, plan, logger)
+//
+// Apply makes the planned changes using ProjectService.Patch and ProjectService.Delete,
+// and records every change in an audit log.
+package retention
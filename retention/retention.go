@@ -0,0 +1,183 @@
+package retention
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+	"github.com/futurice/dependency-track-client-go/internal/semver"
+)
+
+// Policy determines which versions of a project are retained.
+// Versions protected by KeepLast or KeepTags are never deactivated or deleted.
+type Policy struct {
+	KeepLast        int           // Number of most recent versions to keep, regardless of their age
+	KeepTags        []string      // Versions with any of these tags are kept, regardless of their age
+	DeactivateAfter time.Duration // Age after which active versions are deactivated, or 0 to never deactivate versions
+	DeleteAfter     time.Duration // Age after which inactive versions are deleted, or 0 to never delete versions
+}
+
+type Action string
+
+const (
+	ActionKeep       Action = "keep"
+	ActionDeactivate Action = "deactivate"
+	ActionDelete     Action = "delete"
+)
+
+// Decision describes what happens to a version of a project.
+type Decision struct {
+	ProjectUUID   uuid.UUID `json:"projectUuid"`
+	Name          string    `json:"name"`
+	Version       string    `json:"version"`
+	Active        bool      `json:"active"`
+	LastBOMImport time.Time `json:"lastBomImport"` // Zero if no BOM has been imported
+	Action        Action    `json:"action"`
+	Reason        string    `json:"reason"`
+}
+
+func (d Decision) String() string {
+	return fmt.Sprintf("%-10s %s@%s: %s", d.Action, d.Name, d.Version, d.Reason)
+}
+
+// Plan lists the decisions for all versions of the evaluated projects,
+// grouped by project name and ordered from the most recent to the oldest version.
+type Plan struct {
+	Decisions []Decision `json:"decisions"`
+}
+
+// Changes returns the decisions that deactivate or delete a version.
+func (p Plan) Changes() []Decision {
+	var changes []Decision
+	for _, d := range p.Decisions {
+		if d.Action != ActionKeep {
+			changes = append(changes, d)
+		}
+	}
+
+	return changes
+}
+
+// String renders the plan in a human-readable form, one decision per line, followed by a summary.
+func (p Plan) String() string {
+	counts := make(map[Action]int)
+
+	var sb strings.Builder
+	for _, d := range p.Decisions {
+		counts[d.Action]++
+		sb.WriteString(d.String())
+		sb.WriteByte('\n')
+	}
+	fmt.Fprintf(&sb, "%d to keep, %d to deactivate, %d to delete\n",
+		counts[ActionKeep], counts[ActionDeactivate], counts[ActionDelete])
+
+	return sb.String()
+}
+
+// NewPlan fetches all versions of the projects with the given names using ProjectService.GetProjectsForName,
+// and evaluates policy for each of them. Ages are relative to now.
+func NewPlan(ctx context.Context, client *dtrack.Client, policy Policy, now time.Time, names ...string) (plan Plan, err error) {
+	for _, name := range names {
+		var projects []dtrack.Project
+		if projects, err = client.Project.GetProjectsForName(ctx, name, false, false); err != nil {
+			err = fmt.Errorf("failed to fetch versions of %s: %w", name, err)
+			return
+		}
+
+		plan.Decisions = append(plan.Decisions, Evaluate(policy, projects, now)...)
+	}
+
+	return
+}
+
+// Evaluate decides what happens to each of the given versions of a project according to policy.
+// Ages are relative to now. Versions that are deactivated are deleted by a later evaluation at the earliest.
+//
+// Versions are ordered by their last BOM import, and by their version if they were imported
+// at the same time. Versions without a BOM import are considered the oldest, but are always kept,
+// as is the version Dependency-Track marks as the latest version of the project.
+func Evaluate(policy Policy, projects []dtrack.Project, now time.Time) []Decision {
+	sorted := slices.Clone(projects)
+	slices.SortStableFunc(sorted, func(a, b dtrack.Project) int {
+		return -compareRecency(a, b)
+	})
+
+	decisions := make([]Decision, 0, len(sorted))
+	for i, project := range sorted {
+		d := Decision{
+			ProjectUUID: project.UUID,
+			Name:        project.Name,
+			Version:     project.Version,
+			Active:      project.Active,
+			Action:      ActionKeep,
+		}
+		if project.LastBOMImport > 0 {
+			d.LastBOMImport = time.UnixMilli(int64(project.LastBOMImport)).UTC()
+		}
+		age := now.Sub(d.LastBOMImport)
+
+		switch tag := matchingTag(project, policy.KeepTags); {
+		case i < policy.KeepLast:
+			d.Reason = fmt.Sprintf("one of the %d most recent versions", policy.KeepLast)
+		case project.IsLatest:
+			d.Reason = "latest version"
+		case tag != "":
+			d.Reason = fmt.Sprintf("tagged %s", tag)
+		case d.LastBOMImport.IsZero():
+			d.Reason = "no BOM has been imported"
+		case !project.Active && policy.DeleteAfter > 0 && age > policy.DeleteAfter:
+			d.Action = ActionDelete
+			d.Reason = fmt.Sprintf("inactive, and last BOM import is older than %s", formatDuration(policy.DeleteAfter))
+		case project.Active && policy.DeactivateAfter > 0 && age > policy.DeactivateAfter:
+			d.Action = ActionDeactivate
+			d.Reason = fmt.Sprintf("last BOM import is older than %s", formatDuration(policy.DeactivateAfter))
+		default:
+			d.Reason = "within retention period"
+		}
+
+		decisions = append(decisions, d)
+	}
+
+	return decisions
+}
+
+// compareRecency returns a negative number if a was imported before b, and a positive number if after.
+func compareRecency(a, b dtrack.Project) int {
+	if c := cmp.Compare(a.LastBOMImport, b.LastBOMImport); c != 0 {
+		return c
+	}
+
+	versionA, errA := semver.Parse(a.Version)
+	versionB, errB := semver.Parse(b.Version)
+	if errA == nil && errB == nil {
+		return versionA.Compare(versionB)
+	}
+
+	return cmp.Compare(a.Version, b.Version)
+}
+
+func matchingTag(project dtrack.Project, tags []string) string {
+	for _, tag := range project.Tags {
+		if slices.Contains(tags, tag.Name) {
+			return tag.Name
+		}
+	}
+
+	return ""
+}
+
+// formatDuration formats d in days if it is a multiple of a day.
+func formatDuration(d time.Duration) string {
+	const day = 24 * time.Hour
+	if d%day == 0 {
+		return fmt.Sprintf("%d days", d/day)
+	}
+
+	return d.String()
+}
@@ -0,0 +1,192 @@
+package retention
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+	"github.com/futurice/dependency-track-client-go/dtracktest"
+)
+
+var now = time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+func daysAgo(days int) int {
+	return int(now.Add(-time.Duration(days) * 24 * time.Hour).UnixMilli())
+}
+
+var policy = Policy{
+	KeepLast:        2,
+	KeepTags:        []string{"release"},
+	DeactivateAfter: 30 * 24 * time.Hour,
+	DeleteAfter:     90 * 24 * time.Hour,
+}
+
+func TestEvaluate(t *testing.T) {
+	projects := []dtrack.Project{
+		{Name: "acme-app", Version: "1.0.0", Active: true, LastBOMImport: daysAgo(200)},
+		{Name: "acme-app", Version: "1.3.0", Active: true, LastBOMImport: daysAgo(1)},
+		{Name: "acme-app", Version: "1.2.0", Active: true, LastBOMImport: daysAgo(60)},
+		{Name: "acme-app", Version: "1.1.0", Active: false, LastBOMImport: daysAgo(120), Tags: []dtrack.Tag{{Name: "release"}}},
+		{Name: "acme-app", Version: "0.9.0", Active: false, LastBOMImport: daysAgo(100)},
+		{Name: "acme-app", Version: "0.8.0", Active: false, LastBOMImport: daysAgo(50)},
+		{Name: "acme-app", Version: "1.2.1", Active: true, LastBOMImport: daysAgo(60)},
+		{Name: "acme-app", Version: "1.2.2", Active: true, LastBOMImport: daysAgo(10)},
+		{Name: "acme-app", Version: "snapshot", Active: true},
+	}
+
+	decisions := Evaluate(policy, projects, now)
+	require.Len(t, decisions, len(projects))
+
+	var summary []string
+	for _, d := range decisions {
+		summary = append(summary, d.Version+" "+string(d.Action))
+	}
+	require.Equal(t, []string{
+		"1.3.0 keep",
+		"1.2.2 keep",
+		"0.8.0 keep",
+		"1.2.1 deactivate",
+		"1.2.0 deactivate",
+		"0.9.0 delete",
+		"1.1.0 keep",
+		"1.0.0 deactivate",
+		"snapshot keep",
+	}, summary)
+
+	require.Equal(t, "one of the 2 most recent versions", decisions[0].Reason)
+	require.Equal(t, "within retention period", decisions[2].Reason)
+	require.Equal(t, "last BOM import is older than 30 days", decisions[3].Reason)
+	require.Equal(t, "inactive, and last BOM import is older than 90 days", decisions[5].Reason)
+	require.Equal(t, "tagged release", decisions[6].Reason)
+	require.Equal(t, "no BOM has been imported", decisions[8].Reason)
+	require.True(t, decisions[8].LastBOMImport.IsZero())
+
+	require.Empty(t, Plan{Decisions: Evaluate(Policy{}, projects, now)}.Changes())
+}
+
+func TestEvaluate_Latest(t *testing.T) {
+	projects := []dtrack.Project{
+		{Name: "acme-app", Version: "2.0.0-rc.1", Active: true, LastBOMImport: daysAgo(100)},
+		{Name: "acme-app", Version: "1.9.0", Active: true, IsLatest: true, LastBOMImport: daysAgo(200)},
+		{Name: "acme-app", Version: "1.8.0", Active: false, IsLatest: true, LastBOMImport: daysAgo(300)},
+	}
+
+	decisions := Evaluate(Policy{DeactivateAfter: policy.DeactivateAfter, DeleteAfter: policy.DeleteAfter}, projects, now)
+	require.Len(t, decisions, 3)
+	require.Equal(t, ActionDeactivate, decisions[0].Action)
+	for _, d := range decisions[1:] {
+		require.Equal(t, ActionKeep, d.Action, d.Version)
+		require.Equal(t, "latest version", d.Reason)
+	}
+}
+
+func TestNewPlanAndApply(t *testing.T) {
+	server := dtracktest.NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	latest := server.AddProject(dtrack.Project{Name: "acme-app", Version: "1.2.0", Active: true, LastBOMImport: daysAgo(1)})
+	previous := server.AddProject(dtrack.Project{Name: "acme-app", Version: "1.1.0", Active: true, LastBOMImport: daysAgo(20)})
+	stale := server.AddProject(dtrack.Project{Name: "acme-app", Version: "1.0.0", Active: true, LastBOMImport: daysAgo(40)})
+	expired := server.AddProject(dtrack.Project{Name: "acme-app", Version: "0.9.0", Active: false, LastBOMImport: daysAgo(100)})
+	other := server.AddProject(dtrack.Project{Name: "other-app", Version: "0.1.0", Active: true, LastBOMImport: daysAgo(400)})
+
+	plan, err := NewPlan(context.TODO(), client, policy, now, "acme-app")
+	require.NoError(t, err)
+	require.Len(t, plan.Decisions, 4)
+	require.Equal(t, latest.UUID, plan.Decisions[0].ProjectUUID)
+
+	changes := plan.Changes()
+	require.Len(t, changes, 2)
+	require.Equal(t, Decision{
+		ProjectUUID:   stale.UUID,
+		Name:          "acme-app",
+		Version:       "1.0.0",
+		Active:        true,
+		LastBOMImport: time.UnixMilli(int64(stale.LastBOMImport)).UTC(),
+		Action:        ActionDeactivate,
+		Reason:        "last BOM import is older than 30 days",
+	}, changes[0])
+	require.Equal(t, expired.UUID, changes[1].ProjectUUID)
+	require.Equal(t, ActionDelete, changes[1].Action)
+
+	require.Equal(t, `keep       acme-app@1.2.0: one of the 2 most recent versions
+keep       acme-app@1.1.0: one of the 2 most recent versions
+deactivate acme-app@1.0.0: last BOM import is older than 30 days
+delete     acme-app@0.9.0: inactive, and last BOM import is older than 90 days
+2 to keep, 1 to deactivate, 1 to delete
+`, plan.String())
+
+	content, err := json.Marshal(plan)
+	require.NoError(t, err)
+	var decoded Plan
+	require.NoError(t, json.Unmarshal(content, &decoded))
+	require.Equal(t, plan, decoded)
+
+	// Planning is a dry run.
+	require.Len(t, server.Projects(), 5)
+
+	var logs bytes.Buffer
+	auditLog, err := Apply(context.TODO(), client, plan, slog.New(slog.NewJSONHandler(&logs, nil)))
+	require.NoError(t, err)
+	require.Len(t, auditLog, 2)
+	require.Equal(t, changes[0], auditLog[0].Decision)
+	require.Empty(t, auditLog[0].Error)
+	require.False(t, auditLog[0].Time.IsZero())
+	require.Contains(t, logs.String(), `"msg":"retention change applied","action":"deactivate","project_uuid":"`+stale.UUID.String()+`"`)
+
+	project, err := client.Project.Get(context.TODO(), stale.UUID)
+	require.NoError(t, err)
+	require.False(t, project.Active)
+	require.Equal(t, "1.0.0", project.Version)
+
+	_, err = client.Project.Get(context.TODO(), expired.UUID)
+	require.ErrorIs(t, err, dtrack.ErrNotFound)
+
+	for _, kept := range []dtrack.Project{latest, previous, other} {
+		project, err := client.Project.Get(context.TODO(), kept.UUID)
+		require.NoError(t, err)
+		require.True(t, project.Active, kept.Version)
+	}
+}
+
+func TestApply_Error(t *testing.T) {
+	server := dtracktest.NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	first := server.AddProject(dtrack.Project{Name: "acme-app", Version: "1.0.0", Active: true, LastBOMImport: daysAgo(40)})
+	second := server.AddProject(dtrack.Project{Name: "acme-app", Version: "0.9.0", Active: true, LastBOMImport: daysAgo(50)})
+
+	plan, err := NewPlan(context.TODO(), client, Policy{DeactivateAfter: 30 * 24 * time.Hour}, now, "acme-app")
+	require.NoError(t, err)
+	require.Len(t, plan.Changes(), 2)
+
+	server.InjectFailure(dtracktest.Failure{
+		Method:     http.MethodPatch,
+		PathPrefix: "/api/v1/project/" + first.UUID.String(),
+		StatusCode: http.StatusForbidden,
+	})
+
+	auditLog, err := Apply(context.TODO(), client, plan, nil)
+	require.ErrorIs(t, err, dtrack.ErrForbidden)
+	require.ErrorContains(t, err, "failed to deactivate acme-app@1.0.0")
+	require.Len(t, auditLog, 2)
+	require.NotEmpty(t, auditLog[0].Error)
+	require.Empty(t, auditLog[1].Error)
+
+	project, err := client.Project.Get(context.TODO(), second.UUID)
+	require.NoError(t, err)
+	require.False(t, project.Active)
+}
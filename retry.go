@@ -0,0 +1,245 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes if and how failed requests are retried.
+type RetryPolicy struct {
+	MaxAttempts          int           // Maximum number of attempts, including the initial one
+	InitialBackoff       time.Duration // Backoff before the first retry
+	MaxBackoff           time.Duration // Upper bound for the backoff between two attempts
+	Multiplier           float64       // Factor by which the backoff grows with every attempt
+	Jitter               float64       // Fraction (0-1) of the backoff that is randomized
+	MaxRetryAfter        time.Duration // Responses asking to wait longer than this are not retried
+	RetryableMethods     []string      // HTTP methods that may be retried
+	RetryableStatusCodes []int         // HTTP status codes that trigger a retry
+	RetryNonIdempotent   bool          // Additionally retry POST, PUT and PATCH requests
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with sensible defaults.
+// Only idempotent methods are retried, on 429, 502, 503 and 504 responses as well as network errors.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		MaxRetryAfter:  time.Minute,
+		RetryableMethods: []string{
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodOptions,
+			http.MethodDelete,
+		},
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// WithRetryPolicy enables automatic retries of failed requests.
+// Fields of policy that are left empty are populated from DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		defaults := DefaultRetryPolicy()
+
+		if policy.MaxAttempts < 0 {
+			return fmt.Errorf("max attempts must not be negative")
+		}
+		if policy.Jitter < 0 || policy.Jitter > 1 {
+			return fmt.Errorf("jitter must be between 0 and 1")
+		}
+
+		if policy.MaxAttempts == 0 {
+			policy.MaxAttempts = defaults.MaxAttempts
+		}
+		if policy.InitialBackoff <= 0 {
+			policy.InitialBackoff = defaults.InitialBackoff
+		}
+		if policy.MaxBackoff <= 0 {
+			policy.MaxBackoff = defaults.MaxBackoff
+		}
+		if policy.Multiplier < 1 {
+			policy.Multiplier = defaults.Multiplier
+		}
+		if policy.MaxRetryAfter <= 0 {
+			policy.MaxRetryAfter = defaults.MaxRetryAfter
+		}
+		if len(policy.RetryableMethods) == 0 {
+			policy.RetryableMethods = defaults.RetryableMethods
+		}
+		if len(policy.RetryableStatusCodes) == 0 {
+			policy.RetryableStatusCodes = defaults.RetryableStatusCodes
+		}
+
+		c.retryPolicy = &policy
+		return nil
+	}
+}
+
+func (rp RetryPolicy) isRetryableMethod(method string) bool {
+	for _, m := range rp.RetryableMethods {
+		if m == method {
+			return true
+		}
+	}
+
+	if rp.RetryNonIdempotent {
+		switch method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rp RetryPolicy) isRetryableStatus(statusCode int) bool {
+	for _, sc := range rp.RetryableStatusCodes {
+		if sc == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff calculates how long to wait before the given attempt (starting at 2).
+func (rp RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(rp.InitialBackoff) * math.Pow(rp.Multiplier, float64(attempt-2))
+	if d > float64(rp.MaxBackoff) {
+		d = float64(rp.MaxBackoff)
+	}
+
+	if rp.Jitter > 0 {
+		d -= d * rp.Jitter * rand.Float64() // #nosec G404 -- jitter does not require a CSPRNG
+	}
+
+	return time.Duration(d)
+}
+
+// parseRetryAfter parses the value of a Retry-After header,
+// which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		d := date.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+// send performs req, retrying it according to the client's RetryPolicy.
+// It returns the number of attempts that were made.
+func (c Client) send(req *http.Request) (*http.Response, int, error) {
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			if err := rewindBody(req); err != nil {
+				return nil, attempt - 1, err
+			}
+		}
+
+		res, err := c.sendOnce(req, attempt)
+
+		wait, retry := c.shouldRetry(req, res, err, attempt)
+		if !retry {
+			return res, attempt, err
+		}
+
+		if res != nil {
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+			_ = res.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, attempt, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry determines whether another attempt should be made,
+// and how long to wait before making it.
+func (c Client) shouldRetry(req *http.Request, res *http.Response, err error, attempt int) (time.Duration, bool) {
+	rp := c.retryPolicy
+	if rp == nil || attempt >= rp.MaxAttempts {
+		return 0, false
+	}
+	if !rp.isRetryableMethod(req.Method) {
+		return 0, false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false // Body can't be replayed
+	}
+
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || req.Context().Err() != nil {
+			return 0, false
+		}
+		return rp.backoff(attempt + 1), true
+	}
+
+	if !rp.isRetryableStatus(res.StatusCode) {
+		return 0, false
+	}
+
+	wait := rp.backoff(attempt + 1)
+	if retryAfter, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+		if retryAfter > rp.MaxRetryAfter {
+			return 0, false
+		}
+		if retryAfter > wait {
+			wait = retryAfter
+		}
+	}
+
+	return wait, true
+}
+
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.GetBody == nil {
+		return fmt.Errorf("request body can not be replayed")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return fmt.Errorf("failed to replay request body: %w", err)
+	}
+
+	req.Body = body
+	return nil
+}
@@ -0,0 +1,113 @@
+package dtrack
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	t.Run("RetriesIdempotentRequests", func(t *testing.T) {
+		client := setUpRetryClient(t, RetryPolicy{})
+
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+			httpmock.NewStringResponder(http.StatusServiceUnavailable, "").
+				Then(httpmock.NewStringResponder(http.StatusBadGateway, "")).
+				Then(httpmock.NewStringResponder(http.StatusOK, `{"version":"4.8.0"}`)))
+
+		about, err := client.About.Get(context.TODO())
+		require.NoError(t, err)
+		require.Equal(t, "4.8.0", about.Version)
+		require.Equal(t, 3, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("GivesUpAfterMaxAttempts", func(t *testing.T) {
+		client := setUpRetryClient(t, RetryPolicy{MaxAttempts: 2})
+
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+			httpmock.NewStringResponder(http.StatusServiceUnavailable, "unavailable"))
+
+		_, err := client.About.Get(context.TODO())
+		require.Error(t, err)
+		require.Equal(t, 2, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("DoesNotRetryNonIdempotentRequestsByDefault", func(t *testing.T) {
+		client := setUpRetryClient(t, RetryPolicy{})
+
+		httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/bom",
+			httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+		_, err := client.BOM.Upload(context.TODO(), BOMUploadRequest{BOM: "bom"})
+		require.Error(t, err)
+		require.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("ReplaysBodyOfNonIdempotentRequestsOnOptIn", func(t *testing.T) {
+		client := setUpRetryClient(t, RetryPolicy{RetryNonIdempotent: true})
+
+		var bodies []string
+		httpmock.RegisterResponder(http.MethodPost, "http://localhost/api/v1/bom",
+			func(req *http.Request) (*http.Response, error) {
+				body, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				bodies = append(bodies, string(body))
+
+				if len(bodies) == 1 {
+					return httpmock.NewStringResponse(http.StatusTooManyRequests, ""), nil
+				}
+				return httpmock.NewStringResponse(http.StatusOK, `{"token":"foo"}`), nil
+			})
+
+		token, err := client.BOM.PostBom(context.TODO(), BOMUploadRequest{ProjectName: "acme-app", BOM: "bom"})
+		require.NoError(t, err)
+		require.Equal(t, BOMUploadToken("foo"), token)
+		require.Len(t, bodies, 2)
+		require.NotEmpty(t, bodies[0])
+		require.Equal(t, bodies[0], bodies[1])
+	})
+
+	t.Run("DoesNotRetryWhenRetryAfterExceedsLimit", func(t *testing.T) {
+		client := setUpRetryClient(t, RetryPolicy{MaxRetryAfter: time.Second})
+
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+			httpmock.NewStringResponder(http.StatusTooManyRequests, "").HeaderSet(http.Header{"Retry-After": []string{"120"}}))
+
+		_, err := client.About.Get(context.TODO())
+		require.Error(t, err)
+		require.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	d, ok := parseRetryAfter("5", now)
+	require.True(t, ok)
+	require.Equal(t, 5*time.Second, d)
+
+	d, ok = parseRetryAfter(now.Add(10*time.Second).Format(http.TimeFormat), now)
+	require.True(t, ok)
+	require.Equal(t, 10*time.Second, d)
+
+	_, ok = parseRetryAfter("soon", now)
+	require.False(t, ok)
+}
+
+func setUpRetryClient(t *testing.T, policy RetryPolicy) *Client {
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+
+	client, err := NewClient("http://localhost", WithRetryPolicy(policy))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	return client
+}
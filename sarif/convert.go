@@ -0,0 +1,269 @@
+package sarif
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+)
+
+const (
+	cweTaxonomy = "CWE"
+
+	// fingerprintKey is the key of the partial fingerprint identifying a vulnerable component across runs.
+	fingerprintKey = "dependencyTrackFinding/v1"
+)
+
+// Options customizes the runs created by Convert.
+type Options struct {
+	ToolName    string // Name of the tool in the run, defaults to Dependency-Track
+	ToolVersion string // Version of the tool in the run, e.g. the version of the Dependency-Track server
+
+	// Category distinguishes runs of different projects that are uploaded for the same repository.
+	// It is set as the ID of the run's automation details, and omitted if empty.
+	Category string
+}
+
+// helpURIs maps vulnerability sources to the URL of their advisories.
+var helpURIs = map[string]string{
+	"NVD":    "https://nvd.nist.gov/vuln/detail/%s",
+	"GITHUB": "https://github.com/advisories/%s",
+	"OSV":    "https://osv.dev/vulnerability/%s",
+}
+
+// securitySeverities is the score GitHub code scanning uses to rank results of a severity,
+// if the vulnerability doesn't have a CVSS score.
+var securitySeverities = map[dtrack.Severity]string{
+	dtrack.SeverityCritical: "9.5",
+	dtrack.SeverityHigh:     "8.0",
+	dtrack.SeverityMedium:   "5.5",
+	dtrack.SeverityLow:      "2.0",
+}
+
+// Export fetches the findings of a project, including suppressed ones, and converts them to a SARIF log.
+func Export(ctx context.Context, client *dtrack.Client, projectUUID uuid.UUID, opts Options) (l Log, err error) {
+	var findings []dtrack.Finding
+	for finding, err := range client.Finding.All(ctx, projectUUID, true) {
+		if err != nil {
+			return Log{}, fmt.Errorf("failed to fetch findings: %w", err)
+		}
+		findings = append(findings, finding)
+	}
+
+	l = NewLog(Convert(findings, opts))
+	return
+}
+
+// Convert converts findings to a SARIF run.
+//
+// Every vulnerability becomes a rule, which is related to the CWEs of the vulnerability,
+// and every finding becomes a result located at the package URL of the affected component.
+// Suppressed findings, and findings that were analysed as not affected or false positive,
+// are reported with a suppression.
+func Convert(findings []dtrack.Finding, opts Options) Run {
+	run := Run{
+		Tool: Tool{
+			Driver: ToolComponent{
+				Name:           cmp.Or(opts.ToolName, "Dependency-Track"),
+				Version:        opts.ToolVersion,
+				Organization:   "OWASP",
+				InformationURI: "https://dependencytrack.org/",
+			},
+		},
+		Results: make([]Result, 0, len(findings)),
+	}
+	if opts.Category != "" {
+		run.AutomationDetails = &AutomationDetails{ID: opts.Category}
+	}
+
+	ruleIndexes := make(map[string]int)
+	cwes := make(map[int]dtrack.CWE)
+
+	for _, finding := range findings {
+		vuln := finding.Vulnerability
+
+		ruleIndex, ok := ruleIndexes[vuln.VulnID]
+		if !ok {
+			ruleIndex = len(run.Tool.Driver.Rules)
+			ruleIndexes[vuln.VulnID] = ruleIndex
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, convertRule(finding))
+
+			for _, cwe := range vuln.CWEs {
+				cwes[cwe.ID] = cwe
+			}
+		}
+
+		run.Results = append(run.Results, convertResult(finding, ruleIndex))
+	}
+
+	if len(cwes) > 0 {
+		taxonomy := ToolComponent{
+			Name:             cweTaxonomy,
+			Organization:     "MITRE",
+			InformationURI:   "https://cwe.mitre.org/",
+			ShortDescription: &MultiformatMessageString{Text: "The MITRE Common Weakness Enumeration"},
+		}
+		for _, cwe := range slices.SortedFunc(maps.Values(cwes), func(a, b dtrack.CWE) int { return cmp.Compare(a.ID, b.ID) }) {
+			taxonomy.Taxa = append(taxonomy.Taxa, ReportingDescriptor{
+				ID:               strconv.Itoa(cwe.ID),
+				Name:             cwe.Name,
+				ShortDescription: &MultiformatMessageString{Text: cwe.Name},
+				HelpURI:          fmt.Sprintf("https://cwe.mitre.org/data/definitions/%d.html", cwe.ID),
+			})
+		}
+		run.Taxonomies = []ToolComponent{taxonomy}
+	}
+
+	return run
+}
+
+func convertRule(finding dtrack.Finding) ReportingDescriptor {
+	vuln := finding.Vulnerability
+
+	rule := ReportingDescriptor{
+		ID:                   vuln.VulnID,
+		ShortDescription:     &MultiformatMessageString{Text: cmp.Or(vuln.Title, vuln.VulnID)},
+		DefaultConfiguration: &ReportingConfiguration{Level: level(vuln)},
+		Properties: map[string]any{
+			"source": vuln.Source,
+		},
+	}
+	if vuln.Description != "" {
+		rule.FullDescription = &MultiformatMessageString{Text: vuln.Description}
+	}
+	if vuln.Recommendation != "" {
+		rule.Help = &MultiformatMessageString{Text: vuln.Recommendation}
+	}
+
+	if format, ok := helpURIs[vuln.Source]; ok {
+		rule.HelpURI = fmt.Sprintf(format, vuln.VulnID)
+	} else {
+		rule.HelpURI = finding.Attribution.ReferenceURL
+	}
+
+	tags := []string{"security", "vulnerability"}
+	for _, cwe := range vuln.CWEs {
+		tags = append(tags, fmt.Sprintf("external/cwe/cwe-%d", cwe.ID))
+		rule.Relationships = append(rule.Relationships, ReportingDescriptorRelationship{
+			Target: ReportingDescriptorReference{
+				ID:            strconv.Itoa(cwe.ID),
+				ToolComponent: &ToolComponentReference{Name: cweTaxonomy},
+			},
+			Kinds: []string{"superset"},
+		})
+	}
+	rule.Properties["tags"] = tags
+
+	if score := cvssScore(vuln); score > 0 {
+		rule.Properties["security-severity"] = strconv.FormatFloat(score, 'f', 1, 64)
+	} else if score, ok := securitySeverities[dtrack.Severity(vuln.Severity)]; ok {
+		rule.Properties["security-severity"] = score
+	}
+
+	return rule
+}
+
+func convertResult(finding dtrack.Finding, ruleIndex int) Result {
+	vuln := finding.Vulnerability
+	location := componentLocation(finding.Component)
+
+	result := Result{
+		RuleID:    vuln.VulnID,
+		RuleIndex: ruleIndex,
+		Level:     level(vuln),
+		Message:   Message{Text: fmt.Sprintf("%s is affected by %s", location, vuln.VulnID)},
+		Locations: []Location{{
+			PhysicalLocation: &PhysicalLocation{
+				ArtifactLocation: ArtifactLocation{URI: location},
+			},
+			LogicalLocations: []LogicalLocation{{
+				Name:               finding.Component.Name,
+				FullyQualifiedName: location,
+				Kind:               "module",
+			}},
+		}},
+		PartialFingerprints: map[string]string{
+			fingerprintKey: location + "|" + vuln.Source + "|" + vuln.VulnID,
+		},
+		Suppressions: suppressions(finding.Analysis),
+	}
+	if vuln.Title != "" {
+		result.Message.Text += ": " + vuln.Title
+	}
+	if finding.Analysis.State != "" {
+		result.Properties = map[string]any{"analysisState": finding.Analysis.State}
+	}
+
+	return result
+}
+
+// componentLocation returns the package URL of a component, or its coordinates if it doesn't have one.
+func componentLocation(component dtrack.FindingComponent) string {
+	if component.PURL != "" {
+		return component.PURL
+	}
+
+	location := component.Name
+	if component.Group != "" {
+		location = component.Group + "/" + location
+	}
+	if component.Version != "" {
+		location += "@" + component.Version
+	}
+
+	return location
+}
+
+// level determines the level of results of a vulnerability from its severity,
+// falling back to its CVSS score if the severity is unassigned.
+// Vulnerabilities without either are reported as warnings.
+func level(vuln dtrack.FindingVulnerability) Level {
+	switch dtrack.Severity(strings.ToUpper(vuln.Severity)) {
+	case dtrack.SeverityCritical, dtrack.SeverityHigh:
+		return LevelError
+	case dtrack.SeverityMedium:
+		return LevelWarning
+	case dtrack.SeverityLow, dtrack.SeverityInfo:
+		return LevelNote
+	case dtrack.SeverityUnassigned:
+		// Fall back to the CVSS score below.
+	}
+
+	switch score := cvssScore(vuln); {
+	case score >= 7:
+		return LevelError
+	case score >= 4:
+		return LevelWarning
+	case score > 0:
+		return LevelNote
+	default:
+		return LevelWarning
+	}
+}
+
+func cvssScore(vuln dtrack.FindingVulnerability) float64 {
+	return cmp.Or(vuln.CVSSV3BaseScore, vuln.CVSSV2BaseScore)
+}
+
+func suppressions(analysis dtrack.FindingAnalysis) []Suppression {
+	var justification string
+	if state := dtrack.AnalysisState(analysis.State); state != "" && state != dtrack.AnalysisStateNotSet {
+		justification = "Analysed as " + string(state) + " in Dependency-Track"
+	}
+
+	switch {
+	case analysis.Suppressed:
+		return []Suppression{{Kind: SuppressionKindExternal, Status: SuppressionStatusAccepted, Justification: justification}}
+	case analysis.State == string(dtrack.AnalysisStateNotAffected), analysis.State == string(dtrack.AnalysisStateFalsePositive):
+		return []Suppression{{Kind: SuppressionKindExternal, Status: SuppressionStatusUnderReview, Justification: justification}}
+	default:
+		return nil
+	}
+}
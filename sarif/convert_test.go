@@ -0,0 +1,194 @@
+package sarif
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dtrack "github.com/futurice/dependency-track-client-go"
+	"github.com/futurice/dependency-track-client-go/dtracktest"
+)
+
+var log4Shell = dtrack.Finding{
+	Component: dtrack.FindingComponent{
+		Group:   "org.apache.logging.log4j",
+		Name:    "log4j-core",
+		Version: "2.14.1",
+		PURL:    "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+	},
+	Vulnerability: dtrack.FindingVulnerability{
+		VulnID:          "CVE-2021-44228",
+		Source:          "NVD",
+		Title:           "Log4Shell",
+		Description:     "Apache Log4j2 JNDI features do not protect against attacker controlled LDAP endpoints.",
+		Recommendation:  "Upgrade to 2.17.1 or newer.",
+		CVSSV3BaseScore: 10,
+		Severity:        "CRITICAL",
+		SeverityRank:    0,
+		CWEs:            []dtrack.CWE{{ID: 502, Name: "Deserialization of Untrusted Data"}, {ID: 20, Name: "Improper Input Validation"}},
+	},
+}
+
+func TestConvert(t *testing.T) {
+	notAffected := log4Shell
+	notAffected.Component = dtrack.FindingComponent{Group: "org.acme", Name: "shaded-log4j", Version: "1.0.0"}
+	notAffected.Analysis = dtrack.FindingAnalysis{State: string(dtrack.AnalysisStateNotAffected)}
+
+	suppressed := dtrack.Finding{
+		Attribution: dtrack.FindingAttribution{ReferenceURL: "https://ossindex.sonatype.org/vulnerability/sonatype-2021-4682"},
+		Analysis:    dtrack.FindingAnalysis{State: string(dtrack.AnalysisStateFalsePositive), Suppressed: true},
+		Component:   dtrack.FindingComponent{Name: "lodash", Version: "4.17.20", PURL: "pkg:npm/lodash@4.17.20"},
+		Vulnerability: dtrack.FindingVulnerability{
+			VulnID:          "sonatype-2021-4682",
+			Source:          "OSSINDEX",
+			CVSSV2BaseScore: 5,
+			Severity:        "UNASSIGNED",
+			SeverityRank:    5,
+			CWEs:            []dtrack.CWE{{ID: 20, Name: "Improper Input Validation"}},
+		},
+	}
+
+	low := dtrack.Finding{
+		Component:     dtrack.FindingComponent{Name: "minimist", Version: "1.2.5", PURL: "pkg:npm/minimist@1.2.5"},
+		Vulnerability: dtrack.FindingVulnerability{VulnID: "GHSA-xvch-5gv4-984h", Source: "GITHUB", Severity: "LOW", SeverityRank: 3},
+	}
+
+	run := Convert([]dtrack.Finding{log4Shell, notAffected, suppressed, low}, Options{ToolVersion: "4.12.0", Category: "acme-app"})
+
+	require.Equal(t, "Dependency-Track", run.Tool.Driver.Name)
+	require.Equal(t, "4.12.0", run.Tool.Driver.Version)
+	require.Equal(t, &AutomationDetails{ID: "acme-app"}, run.AutomationDetails)
+
+	rules := run.Tool.Driver.Rules
+	require.Len(t, rules, 3)
+	require.Equal(t, ReportingDescriptor{
+		ID:                   "CVE-2021-44228",
+		ShortDescription:     &MultiformatMessageString{Text: "Log4Shell"},
+		FullDescription:      &MultiformatMessageString{Text: log4Shell.Vulnerability.Description},
+		Help:                 &MultiformatMessageString{Text: "Upgrade to 2.17.1 or newer."},
+		HelpURI:              "https://nvd.nist.gov/vuln/detail/CVE-2021-44228",
+		DefaultConfiguration: &ReportingConfiguration{Level: LevelError},
+		Relationships: []ReportingDescriptorRelationship{
+			{Target: ReportingDescriptorReference{ID: "502", ToolComponent: &ToolComponentReference{Name: "CWE"}}, Kinds: []string{"superset"}},
+			{Target: ReportingDescriptorReference{ID: "20", ToolComponent: &ToolComponentReference{Name: "CWE"}}, Kinds: []string{"superset"}},
+		},
+		Properties: map[string]any{
+			"source":            "NVD",
+			"tags":              []string{"security", "vulnerability", "external/cwe/cwe-502", "external/cwe/cwe-20"},
+			"security-severity": "10.0",
+		},
+	}, rules[0])
+	require.Equal(t, "sonatype-2021-4682", rules[1].ID)
+	require.Equal(t, LevelWarning, rules[1].DefaultConfiguration.Level)
+	require.Equal(t, "https://ossindex.sonatype.org/vulnerability/sonatype-2021-4682", rules[1].HelpURI)
+	require.Equal(t, "5.0", rules[1].Properties["security-severity"])
+	require.Equal(t, LevelNote, rules[2].DefaultConfiguration.Level)
+	require.Equal(t, "https://github.com/advisories/GHSA-xvch-5gv4-984h", rules[2].HelpURI)
+	require.Equal(t, "2.0", rules[2].Properties["security-severity"])
+
+	require.Len(t, run.Taxonomies, 1)
+	require.Equal(t, "CWE", run.Taxonomies[0].Name)
+	require.Equal(t, []ReportingDescriptor{
+		{ID: "20", Name: "Improper Input Validation", ShortDescription: &MultiformatMessageString{Text: "Improper Input Validation"}, HelpURI: "https://cwe.mitre.org/data/definitions/20.html"},
+		{ID: "502", Name: "Deserialization of Untrusted Data", ShortDescription: &MultiformatMessageString{Text: "Deserialization of Untrusted Data"}, HelpURI: "https://cwe.mitre.org/data/definitions/502.html"},
+	}, run.Taxonomies[0].Taxa)
+
+	results := run.Results
+	require.Len(t, results, 4)
+	require.Equal(t, Result{
+		RuleID:    "CVE-2021-44228",
+		RuleIndex: 0,
+		Level:     LevelError,
+		Message:   Message{Text: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1 is affected by CVE-2021-44228: Log4Shell"},
+		Locations: []Location{{
+			PhysicalLocation: &PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}},
+			LogicalLocations: []LogicalLocation{{Name: "log4j-core", FullyQualifiedName: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", Kind: "module"}},
+		}},
+		PartialFingerprints: map[string]string{
+			"dependencyTrackFinding/v1": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1|NVD|CVE-2021-44228",
+		},
+	}, results[0])
+
+	require.Equal(t, 0, results[1].RuleIndex)
+	require.Equal(t, "org.acme/shaded-log4j@1.0.0", results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Equal(t, []Suppression{{
+		Kind:          SuppressionKindExternal,
+		Status:        SuppressionStatusUnderReview,
+		Justification: "Analysed as NOT_AFFECTED in Dependency-Track",
+	}}, results[1].Suppressions)
+	require.Equal(t, map[string]any{"analysisState": "NOT_AFFECTED"}, results[1].Properties)
+
+	require.Equal(t, 1, results[2].RuleIndex)
+	require.Equal(t, []Suppression{{
+		Kind:          SuppressionKindExternal,
+		Status:        SuppressionStatusAccepted,
+		Justification: "Analysed as FALSE_POSITIVE in Dependency-Track",
+	}}, results[2].Suppressions)
+
+	require.Equal(t, LevelNote, results[3].Level)
+	require.Empty(t, results[3].Suppressions)
+}
+
+func TestLevel(t *testing.T) {
+	for _, tc := range []struct {
+		vuln  dtrack.FindingVulnerability
+		level Level
+	}{
+		{dtrack.FindingVulnerability{Severity: "HIGH", SeverityRank: 1, CVSSV3BaseScore: 2}, LevelError},
+		{dtrack.FindingVulnerability{Severity: "MEDIUM", SeverityRank: 2}, LevelWarning},
+		{dtrack.FindingVulnerability{Severity: "INFO", SeverityRank: 4}, LevelNote},
+		{dtrack.FindingVulnerability{Severity: "low", SeverityRank: 0}, LevelNote},
+		{dtrack.FindingVulnerability{Severity: "UNASSIGNED", SeverityRank: 5, CVSSV3BaseScore: 7.5}, LevelError},
+		{dtrack.FindingVulnerability{CVSSV2BaseScore: 3.1}, LevelNote},
+		{dtrack.FindingVulnerability{}, LevelWarning},
+	} {
+		require.Equal(t, tc.level, level(tc.vuln), "%+v", tc.vuln)
+	}
+}
+
+func TestExport(t *testing.T) {
+	server := dtracktest.NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	project := server.AddProject(dtrack.Project{Name: "acme-app", Version: "1.0.0", Active: true})
+	server.AddFinding(project.UUID, log4Shell)
+
+	suppressed := log4Shell
+	suppressed.Vulnerability.VulnID = "CVE-2021-45046"
+	suppressed.Analysis = dtrack.FindingAnalysis{State: string(dtrack.AnalysisStateNotAffected), Suppressed: true}
+	server.AddFinding(project.UUID, suppressed)
+
+	log, err := Export(context.TODO(), client, project.UUID, Options{})
+	require.NoError(t, err)
+	require.Len(t, log.Runs, 1)
+	require.Len(t, log.Runs[0].Results, 2)
+	require.Equal(t, SuppressionStatusAccepted, log.Runs[0].Results[1].Suppressions[0].Status)
+
+	content, err := json.Marshal(log)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(content, &decoded))
+	require.Equal(t, "2.1.0", decoded["version"])
+	require.Equal(t, "https://json.schemastore.org/sarif-2.1.0.json", decoded["$schema"])
+
+	server.InjectFailure(dtracktest.Failure{
+		Method:     http.MethodGet,
+		PathPrefix: "/api/v1/finding/project/" + project.UUID.String(),
+		StatusCode: http.StatusUnauthorized,
+	})
+	_, err = Export(context.TODO(), client, project.UUID, Options{})
+	require.ErrorIs(t, err, dtrack.ErrUnauthorized)
+}
+
+func TestNewLog(t *testing.T) {
+	content, err := json.Marshal(NewLog())
+	require.NoError(t, err)
+	require.JSONEq(t, `{"version": "2.1.0", "$schema": "https://json.schemastore.org/sarif-2.1.0.json", "runs": []}`, string(content))
+}
@@ -0,0 +1,15 @@
+// Package sarif exports the findings of Dependency-Track projects in the Static Analysis Results
+// Interchange Format (SARIF) 2.1.0, e.g. for upload to GitHub code scanning.
+//
+// Vulnerabilities are exported as rules, and the CWEs of a vulnerability as taxa of the CWE taxonomy.
+// Every finding is exported as a result located at the package URL of the vulnerable component.
+// The analysis of a finding is exported as a suppression:
+//
+//	log, err := sarif.Export(ctx, client, projectUUID, sarif.Options{Category: "acme-app"})
+//	if err != nil {
+//		return err
+//	}
+//	content, err := json.Marshal(log)
+//
+// Convert creates a run from findings that have already been fetched.
+package sarif
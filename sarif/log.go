@@ -0,0 +1,161 @@
+package sarif
+
+const (
+	// Version is the SARIF version of logs created by this package.
+	Version = "2.1.0"
+
+	// SchemaURI is the URI of the JSON schema of SARIF 2.1.0.
+	SchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// Log is the top-level object of a SARIF file.
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []Run  `json:"runs"`
+}
+
+// NewLog creates a log containing runs.
+func NewLog(runs ...Run) Log {
+	if runs == nil {
+		runs = make([]Run, 0)
+	}
+
+	return Log{
+		Version: Version,
+		Schema:  SchemaURI,
+		Runs:    runs,
+	}
+}
+
+// Run describes a single invocation of an analysis tool and its results.
+type Run struct {
+	Tool              Tool               `json:"tool"`
+	AutomationDetails *AutomationDetails `json:"automationDetails,omitempty"`
+	Taxonomies        []ToolComponent    `json:"taxonomies,omitempty"`
+	Results           []Result           `json:"results"`
+}
+
+// AutomationDetails identifies a run. GitHub code scanning uses its ID as the category of an analysis.
+type AutomationDetails struct {
+	ID string `json:"id"`
+}
+
+type Tool struct {
+	Driver ToolComponent `json:"driver"`
+}
+
+// ToolComponent is either the analysis tool of a run, or a taxonomy such as CWE.
+type ToolComponent struct {
+	Name             string                    `json:"name"`
+	Version          string                    `json:"version,omitempty"`
+	Organization     string                    `json:"organization,omitempty"`
+	InformationURI   string                    `json:"informationUri,omitempty"`
+	ShortDescription *MultiformatMessageString `json:"shortDescription,omitempty"`
+	Rules            []ReportingDescriptor     `json:"rules,omitempty"`
+	Taxa             []ReportingDescriptor     `json:"taxa,omitempty"`
+}
+
+// ReportingDescriptor describes either a rule of a tool, or a taxon of a taxonomy.
+type ReportingDescriptor struct {
+	ID                   string                            `json:"id"`
+	Name                 string                            `json:"name,omitempty"`
+	ShortDescription     *MultiformatMessageString         `json:"shortDescription,omitempty"`
+	FullDescription      *MultiformatMessageString         `json:"fullDescription,omitempty"`
+	Help                 *MultiformatMessageString         `json:"help,omitempty"`
+	HelpURI              string                            `json:"helpUri,omitempty"`
+	DefaultConfiguration *ReportingConfiguration           `json:"defaultConfiguration,omitempty"`
+	Relationships        []ReportingDescriptorRelationship `json:"relationships,omitempty"`
+	Properties           map[string]any                    `json:"properties,omitempty"`
+}
+
+type ReportingConfiguration struct {
+	Level Level `json:"level"`
+}
+
+// ReportingDescriptorRelationship relates a rule to a taxon, e.g. a vulnerability to a CWE.
+type ReportingDescriptorRelationship struct {
+	Target ReportingDescriptorReference `json:"target"`
+	Kinds  []string                     `json:"kinds,omitempty"`
+}
+
+type ReportingDescriptorReference struct {
+	ID            string                  `json:"id"`
+	ToolComponent *ToolComponentReference `json:"toolComponent,omitempty"`
+}
+
+type ToolComponentReference struct {
+	Name string `json:"name"`
+}
+
+type MultiformatMessageString struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Level is the severity of a result.
+type Level string
+
+const (
+	LevelNone    Level = "none"
+	LevelNote    Level = "note"
+	LevelWarning Level = "warning"
+	LevelError   Level = "error"
+)
+
+// Result is a single finding of a run.
+type Result struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               Level             `json:"level"`
+	Message             Message           `json:"message"`
+	Locations           []Location        `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Suppressions        []Suppression     `json:"suppressions,omitempty"`
+	Properties          map[string]any    `json:"properties,omitempty"`
+}
+
+type Location struct {
+	PhysicalLocation *PhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []LogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+}
+
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type LogicalLocation struct {
+	Name               string `json:"name,omitempty"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// Suppression records that a result was suppressed, or proposed to be suppressed, outside of the analysis tool.
+type Suppression struct {
+	Kind          SuppressionKind   `json:"kind"`
+	Status        SuppressionStatus `json:"status,omitempty"`
+	Justification string            `json:"justification,omitempty"`
+}
+
+type SuppressionKind string
+
+const (
+	SuppressionKindInSource SuppressionKind = "inSource"
+	SuppressionKindExternal SuppressionKind = "external"
+)
+
+type SuppressionStatus string
+
+const (
+	SuppressionStatusAccepted    SuppressionStatus = "accepted"
+	SuppressionStatusUnderReview SuppressionStatus = "underReview"
+	SuppressionStatusRejected    SuppressionStatus = "rejected"
+)
@@ -0,0 +1,372 @@
+package spdx
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/futurice/dependency-track-client-go/cyclonedx"
+)
+
+// ConversionReport lists the parts of a document that could not be converted to CycloneDX.
+type ConversionReport struct {
+	Unmapped []UnmappedField `json:"unmapped"`
+}
+
+// UnmappedField is a field of a document that has no equivalent in CycloneDX,
+// or whose value could not be converted.
+type UnmappedField struct {
+	Element string `json:"element"` // SPDX identifier of the element the field belongs to
+	Field   string `json:"field"`   // Name of the field in the JSON format, e.g. sourceInfo
+	Value   string `json:"value,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+func (f UnmappedField) String() string {
+	s := f.Element + ": " + f.Field
+	if f.Value != "" {
+		s += fmt.Sprintf(" %q", f.Value)
+	}
+
+	return s + ": " + f.Reason
+}
+
+// checksumAlgorithms maps SPDX checksum algorithms to CycloneDX hash algorithms.
+var checksumAlgorithms = map[ChecksumAlgorithm]string{
+	ChecksumAlgorithmMD5:         "MD5",
+	ChecksumAlgorithmSHA1:        "SHA-1",
+	ChecksumAlgorithmSHA256:      "SHA-256",
+	ChecksumAlgorithmSHA384:      "SHA-384",
+	ChecksumAlgorithmSHA512:      "SHA-512",
+	ChecksumAlgorithmSHA3_256:    "SHA3-256",
+	ChecksumAlgorithmSHA3_384:    "SHA3-384",
+	ChecksumAlgorithmSHA3_512:    "SHA3-512",
+	ChecksumAlgorithmBLAKE2b_256: "BLAKE2b-256",
+	ChecksumAlgorithmBLAKE2b_384: "BLAKE2b-384",
+	ChecksumAlgorithmBLAKE2b_512: "BLAKE2b-512",
+	ChecksumAlgorithmBLAKE3:      "BLAKE3",
+}
+
+// componentTypes maps primary package purposes to CycloneDX component types.
+// Packages without a purpose, or with a purpose that has no equivalent, become libraries.
+var componentTypes = map[string]cyclonedx.ComponentType{
+	"APPLICATION":      cyclonedx.ComponentTypeApplication,
+	"FRAMEWORK":        cyclonedx.ComponentTypeFramework,
+	"LIBRARY":          cyclonedx.ComponentTypeLibrary,
+	"CONTAINER":        cyclonedx.ComponentTypeContainer,
+	"OPERATING-SYSTEM": cyclonedx.ComponentTypeOS,
+	"DEVICE":           cyclonedx.ComponentTypeDevice,
+	"FIRMWARE":         cyclonedx.ComponentTypeFirmware,
+	"FILE":             cyclonedx.ComponentTypeFile,
+}
+
+// dependencyOfRelationships are relationships where the related element depends on the element.
+var dependencyOfRelationships = []RelationshipType{
+	RelationshipDependencyOf,
+	RelationshipBuildDependencyOf,
+	RelationshipDevDependencyOf,
+	RelationshipOptionalDependencyOf,
+	RelationshipProvidedDependencyOf,
+	RelationshipRuntimeDependencyOf,
+	RelationshipTestDependencyOf,
+}
+
+// Convert converts doc to a CycloneDX document.
+//
+// Packages become components, identified by their SPDX identifier as bom-ref. If the document
+// describes a single package, it becomes the metadata component. Dependency relationships between
+// packages become dependencies. Files, snippets and annotations are not converted.
+func Convert(doc *Document) (*cyclonedx.BOM, ConversionReport, error) {
+	if !strings.HasPrefix(doc.SPDXVersion, "SPDX-2.") {
+		return nil, ConversionReport{}, fmt.Errorf("spdx version %q is not supported", doc.SPDXVersion)
+	}
+
+	c := converter{
+		doc:      doc,
+		packages: make(map[string]bool, len(doc.Packages)),
+		licenses: make(map[string]string, len(doc.ExtractedLicensingInfos)),
+	}
+	for _, pkg := range doc.Packages {
+		c.packages[pkg.SPDXID] = true
+	}
+	for _, license := range doc.ExtractedLicensingInfos {
+		c.licenses[license.LicenseID] = license.Name
+	}
+
+	bom := c.convert()
+	return bom, ConversionReport{Unmapped: c.unmapped}, nil
+}
+
+type converter struct {
+	doc      *Document
+	packages map[string]bool   // SPDX identifiers of all packages
+	licenses map[string]string // LicenseRef- identifier -> name
+	unmapped []UnmappedField
+}
+
+func (c *converter) unmappable(element, field, value, reason string) {
+	c.unmapped = append(c.unmapped, UnmappedField{Element: element, Field: field, Value: value, Reason: reason})
+}
+
+func (c *converter) convert() *cyclonedx.BOM {
+	doc := c.doc
+	docID := cmp.Or(doc.SPDXID, DocumentID)
+
+	bom := &cyclonedx.BOM{
+		BOMFormat:   cyclonedx.BOMFormat,
+		SpecVersion: cyclonedx.SpecVersion1_4,
+		Version:     1,
+		Metadata:    &cyclonedx.Metadata{Timestamp: doc.CreationInfo.Created},
+	}
+	if doc.DocumentNamespace != "" {
+		// Derive the serial number from the namespace, so that converting a document twice yields the same serial number.
+		bom.SerialNumber = "urn:uuid:" + uuid.NewSHA1(uuid.NameSpaceURL, []byte(doc.DocumentNamespace)).String()
+	}
+
+	for _, creator := range doc.CreationInfo.Creators {
+		c.unmappable(docID, "creationInfo.creators", creator, "creators are not converted")
+	}
+	if doc.CreationInfo.Comment != "" {
+		c.unmappable(docID, "creationInfo.comment", doc.CreationInfo.Comment, "no equivalent in CycloneDX")
+	}
+	if doc.Comment != "" {
+		c.unmappable(docID, "comment", doc.Comment, "no equivalent in CycloneDX")
+	}
+	for _, ref := range doc.ExternalDocumentRefs {
+		c.unmappable(docID, "externalDocumentRefs", ref.ExternalDocumentID, "references to other documents are not resolved")
+	}
+	for range doc.Annotations {
+		c.unmappable(docID, "annotations", "", "annotations are not converted")
+	}
+	for _, file := range doc.Files {
+		c.unmappable(file.SPDXID, "files", file.FileName, "files are not converted")
+	}
+	for _, snippet := range doc.Snippets {
+		c.unmappable(snippet.SPDXID, "snippets", snippet.Name, "snippets are not converted")
+	}
+
+	dependencies := make(map[string][]string)
+	described := slices.Clone(doc.DocumentDescribes)
+	for _, r := range doc.Relationships {
+		switch {
+		case r.Type == RelationshipDescribes && r.Element == docID:
+			described = appendUnique(described, r.RelatedElement)
+		case r.Type == RelationshipDescribedBy && r.RelatedElement == docID:
+			described = appendUnique(described, r.Element)
+		case r.Type == RelationshipDependsOn:
+			c.dependency(dependencies, r, r.Element, r.RelatedElement)
+		case slices.Contains(dependencyOfRelationships, r.Type):
+			c.dependency(dependencies, r, r.RelatedElement, r.Element)
+		default:
+			c.unmappable(r.Element, "relationships", fmt.Sprintf("%s %s %s", r.Element, r.Type, r.RelatedElement), "relationship type is not converted")
+		}
+	}
+
+	components := make([]cyclonedx.Component, 0, len(doc.Packages))
+	for _, pkg := range doc.Packages {
+		components = append(components, c.component(pkg))
+	}
+
+	// A single described package is the subject of the document. Multiple described packages
+	// are grouped under a component representing the document itself.
+	switch {
+	case len(described) == 1 && c.packages[described[0]]:
+		i := slices.IndexFunc(components, func(component cyclonedx.Component) bool { return component.BOMRef == described[0] })
+		subject := components[i]
+		bom.Metadata.Component = &subject
+		components = slices.Delete(components, i, i+1)
+	case len(described) > 1:
+		bom.Metadata.Component = &cyclonedx.Component{
+			Type:   cyclonedx.ComponentTypeApplication,
+			BOMRef: docID,
+			Name:   doc.Name,
+		}
+		for _, ref := range described {
+			if c.packages[ref] {
+				dependencies[docID] = appendUnique(dependencies[docID], ref)
+			}
+		}
+	}
+	bom.Components = components
+
+	// Declare dependencies in the order of packages, rather than in map order.
+	refs := []string{docID}
+	for _, pkg := range doc.Packages {
+		refs = append(refs, pkg.SPDXID)
+	}
+	for _, ref := range refs {
+		if dependsOn, ok := dependencies[ref]; ok {
+			bom.Dependencies = append(bom.Dependencies, cyclonedx.Dependency{Ref: ref, DependsOn: dependsOn})
+		}
+	}
+
+	return bom
+}
+
+// dependency records that element depends on dependency, if both are packages.
+func (c *converter) dependency(dependencies map[string][]string, r Relationship, element, dependency string) {
+	if !c.packages[element] || !c.packages[dependency] {
+		c.unmappable(r.Element, "relationships", fmt.Sprintf("%s %s %s", r.Element, r.Type, r.RelatedElement), "only relationships between packages are converted")
+		return
+	}
+
+	dependencies[element] = appendUnique(dependencies[element], dependency)
+}
+
+func (c *converter) component(pkg Package) cyclonedx.Component {
+	component := cyclonedx.Component{
+		Type:        cyclonedx.ComponentTypeLibrary,
+		BOMRef:      pkg.SPDXID,
+		Name:        pkg.Name,
+		Version:     pkg.VersionInfo,
+		Description: pkg.Description,
+		Publisher:   actorName(pkg.Supplier),
+		Author:      actorName(pkg.Originator),
+	}
+
+	if pkg.PrimaryPackagePurpose != "" {
+		if componentType, ok := componentTypes[pkg.PrimaryPackagePurpose]; ok {
+			component.Type = componentType
+		} else {
+			c.unmappable(pkg.SPDXID, "primaryPackagePurpose", pkg.PrimaryPackagePurpose, "no equivalent component type, converted to library")
+		}
+	}
+
+	if component.Description == "" {
+		component.Description = pkg.Summary
+	} else if pkg.Summary != "" {
+		c.unmappable(pkg.SPDXID, "summary", pkg.Summary, "description is used instead")
+	}
+
+	if hasValue(pkg.CopyrightText) {
+		component.Copyright = pkg.CopyrightText
+	}
+
+	for _, checksum := range pkg.Checksums {
+		if algorithm, ok := checksumAlgorithms[checksum.Algorithm]; ok {
+			component.Hashes = append(component.Hashes, cyclonedx.Hash{Algorithm: algorithm, Value: strings.ToLower(checksum.Value)})
+		} else {
+			c.unmappable(pkg.SPDXID, "checksums", string(checksum.Algorithm), "checksum algorithm is not supported by CycloneDX")
+		}
+	}
+
+	c.license(pkg, &component)
+	c.externalRefs(pkg, &component)
+
+	if hasValue(pkg.Homepage) {
+		component.ExternalReferences = append(component.ExternalReferences, cyclonedx.ExternalReference{Type: "website", URL: pkg.Homepage})
+	}
+	if hasValue(pkg.DownloadLocation) {
+		referenceType := "distribution"
+		if strings.HasPrefix(pkg.DownloadLocation, "git+") || strings.HasPrefix(pkg.DownloadLocation, "hg+") ||
+			strings.HasPrefix(pkg.DownloadLocation, "svn+") || strings.HasPrefix(pkg.DownloadLocation, "bzr+") {
+			referenceType = "vcs"
+		}
+		component.ExternalReferences = append(component.ExternalReferences, cyclonedx.ExternalReference{Type: referenceType, URL: pkg.DownloadLocation})
+	}
+
+	for _, field := range []struct{ name, value string }{
+		{"packageFileName", pkg.PackageFileName},
+		{"sourceInfo", pkg.SourceInfo},
+		{"licenseComments", pkg.LicenseComments},
+		{"comment", pkg.Comment},
+		{"releaseDate", pkg.ReleaseDate},
+		{"builtDate", pkg.BuiltDate},
+		{"validUntilDate", pkg.ValidUntilDate},
+	} {
+		if field.value != "" {
+			c.unmappable(pkg.SPDXID, field.name, field.value, "no equivalent in CycloneDX")
+		}
+	}
+	if pkg.VerificationCode != nil {
+		c.unmappable(pkg.SPDXID, "packageVerificationCode", pkg.VerificationCode.Value, "no equivalent in CycloneDX")
+	}
+	for _, text := range pkg.AttributionTexts {
+		c.unmappable(pkg.SPDXID, "attributionTexts", text, "no equivalent in CycloneDX")
+	}
+	for range pkg.Annotations {
+		c.unmappable(pkg.SPDXID, "annotations", "", "annotations are not converted")
+	}
+
+	return component
+}
+
+// license converts the concluded license of pkg, or its declared license if none was concluded.
+func (c *converter) license(pkg Package, component *cyclonedx.Component) {
+	expression := pkg.LicenseDeclared
+	if hasValue(pkg.LicenseConcluded) {
+		expression = pkg.LicenseConcluded
+		if hasValue(pkg.LicenseDeclared) && pkg.LicenseDeclared != pkg.LicenseConcluded {
+			c.unmappable(pkg.SPDXID, "licenseDeclared", pkg.LicenseDeclared, "differs from the concluded license, which is used instead")
+		}
+	}
+	if !hasValue(expression) {
+		return
+	}
+
+	switch {
+	case strings.ContainsAny(expression, " ()"):
+		component.Licenses = cyclonedx.Licenses{{Expression: expression}}
+	case strings.Contains(expression, "LicenseRef-"):
+		component.Licenses = cyclonedx.Licenses{{License: &cyclonedx.License{Name: cmp.Or(c.licenses[expression], expression)}}}
+	default:
+		component.Licenses = cyclonedx.Licenses{{License: &cyclonedx.License{ID: expression}}}
+	}
+
+	for _, license := range pkg.LicenseInfoFromFiles {
+		if hasValue(license) {
+			c.unmappable(pkg.SPDXID, "licenseInfoFromFiles", license, "licenses of files are not converted")
+		}
+	}
+}
+
+func (c *converter) externalRefs(pkg Package, component *cyclonedx.Component) {
+	for _, ref := range pkg.ExternalRefs {
+		// SPDX 2.2 uses underscores in categories, 2.3 uses dashes.
+		category := ExternalRefCategory(strings.ReplaceAll(string(ref.Category), "_", "-"))
+
+		switch {
+		case category == ExternalRefCategoryPackageManager && ref.Type == "purl" && component.PURL == "":
+			component.PURL = ref.Locator
+		case category == ExternalRefCategorySecurity && (ref.Type == "cpe23Type" || ref.Type == "cpe22Type") && component.CPE == "":
+			component.CPE = ref.Locator
+		case category == ExternalRefCategorySecurity && ref.Type == "advisory":
+			component.ExternalReferences = append(component.ExternalReferences, cyclonedx.ExternalReference{Type: "advisories", URL: ref.Locator, Comment: ref.Comment})
+		case category == ExternalRefCategorySecurity && (ref.Type == "fix" || ref.Type == "url"):
+			component.ExternalReferences = append(component.ExternalReferences, cyclonedx.ExternalReference{Type: "other", URL: ref.Locator, Comment: ref.Comment})
+		case ref.Type == "purl" || ref.Type == "cpe23Type" || ref.Type == "cpe22Type":
+			c.unmappable(pkg.SPDXID, "externalRefs", ref.Locator, "component already has a "+ref.Type)
+		default:
+			c.unmappable(pkg.SPDXID, "externalRefs", ref.Locator, fmt.Sprintf("reference type %s %s is not converted", ref.Category, ref.Type))
+		}
+	}
+}
+
+// actorName returns the name of a supplier or originator, e.g. "ExampleCodeInc." for "Organization: ExampleCodeInc.".
+func actorName(actor string) string {
+	if !hasValue(actor) {
+		return ""
+	}
+
+	if _, name, ok := strings.Cut(actor, ":"); ok {
+		return strings.TrimSpace(name)
+	}
+
+	return actor
+}
+
+// hasValue reports whether s is neither empty, nor NOASSERTION or NONE.
+func hasValue(s string) bool {
+	return s != "" && s != NoAssertion && s != None
+}
+
+func appendUnique(s []string, v string) []string {
+	if slices.Contains(s, v) {
+		return s
+	}
+
+	return append(s, v)
+}
@@ -0,0 +1,111 @@
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/futurice/dependency-track-client-go/cyclonedx"
+)
+
+func TestConvert(t *testing.T) {
+	for _, path := range []string{"./testdata/document.json", "./testdata/document.spdx"} {
+		t.Run(path, func(t *testing.T) {
+			doc, _, err := Parse(readFile(t, path))
+			require.NoError(t, err)
+
+			bom, report, err := Convert(doc)
+			require.NoError(t, err)
+
+			require.Equal(t, "urn:uuid:7d592289-eebb-5000-8fad-bd6c4f8c644c", bom.SerialNumber)
+			require.Equal(t, "2024-01-01T00:00:00Z", bom.Metadata.Timestamp)
+			require.Equal(t, &cyclonedx.Component{
+				Type:      cyclonedx.ComponentTypeApplication,
+				BOMRef:    "SPDXRef-Package-acme-app",
+				Name:      "acme-app",
+				Version:   "1.0.0",
+				Publisher: "Acme Inc.",
+				Licenses:  cyclonedx.Licenses{{License: &cyclonedx.License{ID: "MIT"}}},
+				PURL:      "pkg:npm/acme-app@1.0.0",
+			}, bom.Metadata.Component)
+
+			require.Equal(t, []cyclonedx.Component{
+				{
+					Type:      cyclonedx.ComponentTypeLibrary,
+					BOMRef:    "SPDXRef-Package-lodash",
+					Author:    "John-David Dalton",
+					Name:      "lodash",
+					Version:   "4.17.21",
+					Hashes:    []cyclonedx.Hash{{Algorithm: "SHA-1", Value: "679591c564c3bffaae8454cf0b3df370c3d6911c"}},
+					Licenses:  cyclonedx.Licenses{{License: &cyclonedx.License{ID: "MIT"}}},
+					Copyright: "Copyright OpenJS Foundation and other contributors",
+					CPE:       "cpe:2.3:a:lodash:lodash:4.17.21:*:*:*:*:*:*:*",
+					PURL:      "pkg:npm/lodash@4.17.21",
+					ExternalReferences: []cyclonedx.ExternalReference{
+						{Type: "website", URL: "https://lodash.com/"},
+						{Type: "distribution", URL: "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz"},
+					},
+				},
+				{
+					Type:               cyclonedx.ComponentTypeLibrary,
+					BOMRef:             "SPDXRef-Package-log4j-core",
+					Name:               "log4j-core",
+					Version:            "2.17.1",
+					Licenses:           cyclonedx.Licenses{{Expression: "(Apache-2.0 OR MIT)"}},
+					PURL:               "pkg:maven/org.apache.logging.log4j/log4j-core@2.17.1",
+					ExternalReferences: []cyclonedx.ExternalReference{{Type: "vcs", URL: "git+https://github.com/apache/logging-log4j2.git"}},
+				},
+				{
+					Type:        cyclonedx.ComponentTypeLibrary,
+					BOMRef:      "SPDXRef-Package-custom-lib",
+					Name:        "custom-lib",
+					Version:     "0.1.0",
+					Description: "Internal helpers.\nNot published.",
+					Licenses:    cyclonedx.Licenses{{License: &cyclonedx.License{Name: "Acme Custom License"}}},
+				},
+			}, bom.Components)
+
+			require.Equal(t, []cyclonedx.Dependency{
+				{Ref: "SPDXRef-Package-acme-app", DependsOn: []string{"SPDXRef-Package-lodash", "SPDXRef-Package-log4j-core"}},
+				{Ref: "SPDXRef-Package-lodash", DependsOn: []string{"SPDXRef-Package-custom-lib"}},
+			}, bom.Dependencies)
+
+			require.Equal(t, []UnmappedField{
+				{Element: "SPDXRef-DOCUMENT", Field: "creationInfo.creators", Value: "Tool: syft-1.0.0", Reason: "creators are not converted"},
+				{Element: "SPDXRef-DOCUMENT", Field: "creationInfo.creators", Value: "Organization: Acme Inc.", Reason: "creators are not converted"},
+				{Element: "SPDXRef-File-readme", Field: "files", Value: "./README.md", Reason: "files are not converted"},
+				{Element: "SPDXRef-Package-acme-app", Field: "relationships", Value: "SPDXRef-Package-acme-app CONTAINS SPDXRef-File-readme", Reason: "relationship type is not converted"},
+				{Element: "SPDXRef-Package-lodash", Field: "checksums", Value: "MD2", Reason: "checksum algorithm is not supported by CycloneDX"},
+				{Element: "SPDXRef-Package-lodash", Field: "sourceInfo", Value: "built from the npm tarball", Reason: "no equivalent in CycloneDX"},
+				{Element: "SPDXRef-Package-log4j-core", Field: "externalRefs", Value: "swh:1:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2", Reason: "reference type PERSISTENT-ID swh is not converted"},
+			}, report.Unmapped)
+
+			content, err := cyclonedx.Marshal(bom, cyclonedx.FormatJSON)
+			require.NoError(t, err)
+			require.Empty(t, cyclonedx.Validate(content))
+		})
+	}
+}
+
+func TestConvert_MultipleDescribedPackages(t *testing.T) {
+	bom, _, err := Convert(&Document{
+		SPDXVersion: "SPDX-2.2",
+		SPDXID:      DocumentID,
+		Name:        "product",
+		Packages: []Package{
+			{SPDXID: "SPDXRef-backend", Name: "backend"},
+			{SPDXID: "SPDXRef-frontend", Name: "frontend"},
+		},
+		DocumentDescribes: []string{"SPDXRef-backend", "SPDXRef-frontend"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, &cyclonedx.Component{Type: cyclonedx.ComponentTypeApplication, BOMRef: DocumentID, Name: "product"}, bom.Metadata.Component)
+	require.Len(t, bom.Components, 2)
+	require.Equal(t, []cyclonedx.Dependency{{Ref: DocumentID, DependsOn: []string{"SPDXRef-backend", "SPDXRef-frontend"}}}, bom.Dependencies)
+}
+
+func TestConvert_UnsupportedVersion(t *testing.T) {
+	_, _, err := Convert(&Document{SPDXVersion: "SPDX-3.0"})
+	require.EqualError(t, err, `spdx version "SPDX-3.0" is not supported`)
+}
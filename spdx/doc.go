@@ -0,0 +1,6 @@
+// Package spdx provides a model of SPDX 2.2 and 2.3 documents, as well as functionality to
+// parse them and convert them to CycloneDX, which is the only format Dependency-Track accepts.
+//
+// Both the JSON and the tag-value formats are supported. Unknown fields are ignored when parsing.
+// Conversion is lossy: fields without a CycloneDX equivalent are listed in a ConversionReport.
+package spdx
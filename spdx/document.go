@@ -0,0 +1,183 @@
+package spdx
+
+const (
+	// NoAssertion indicates that no attempt was made to determine a value,
+	// or that the value is intentionally left undetermined.
+	NoAssertion = "NOASSERTION"
+
+	// None indicates that a value does not exist.
+	None = "NONE"
+
+	// DocumentID is the SPDX identifier of documents.
+	DocumentID = "SPDXRef-DOCUMENT"
+)
+
+type Document struct {
+	SPDXVersion             string                   `json:"spdxVersion"`
+	DataLicense             string                   `json:"dataLicense"`
+	SPDXID                  string                   `json:"SPDXID"`
+	Name                    string                   `json:"name"`
+	DocumentNamespace       string                   `json:"documentNamespace"`
+	Comment                 string                   `json:"comment,omitempty"`
+	CreationInfo            CreationInfo             `json:"creationInfo"`
+	ExternalDocumentRefs    []ExternalDocumentRef    `json:"externalDocumentRefs,omitempty"`
+	DocumentDescribes       []string                 `json:"documentDescribes,omitempty"`
+	Packages                []Package                `json:"packages,omitempty"`
+	Files                   []File                   `json:"files,omitempty"`
+	Snippets                []Snippet                `json:"snippets,omitempty"`
+	Relationships           []Relationship           `json:"relationships,omitempty"`
+	Annotations             []Annotation             `json:"annotations,omitempty"`
+	ExtractedLicensingInfos []ExtractedLicensingInfo `json:"hasExtractedLicensingInfos,omitempty"`
+}
+
+type CreationInfo struct {
+	Created            string   `json:"created"`
+	Creators           []string `json:"creators"`
+	LicenseListVersion string   `json:"licenseListVersion,omitempty"`
+	Comment            string   `json:"comment,omitempty"`
+}
+
+type ExternalDocumentRef struct {
+	ExternalDocumentID string   `json:"externalDocumentId"`
+	SPDXDocument       string   `json:"spdxDocument"`
+	Checksum           Checksum `json:"checksum"`
+}
+
+type Package struct {
+	SPDXID                string            `json:"SPDXID"`
+	Name                  string            `json:"name"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	PackageFileName       string            `json:"packageFileName,omitempty"`
+	Supplier              string            `json:"supplier,omitempty"`   // e.g. "Organization: ExampleCodeInc."
+	Originator            string            `json:"originator,omitempty"` // e.g. "Person: Jane Doe (jane@example.com)"
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         *bool             `json:"filesAnalyzed,omitempty"`
+	VerificationCode      *VerificationCode `json:"packageVerificationCode,omitempty"`
+	Checksums             []Checksum        `json:"checksums,omitempty"`
+	Homepage              string            `json:"homepage,omitempty"`
+	SourceInfo            string            `json:"sourceInfo,omitempty"`
+	LicenseConcluded      string            `json:"licenseConcluded,omitempty"`
+	LicenseInfoFromFiles  []string          `json:"licenseInfoFromFiles,omitempty"`
+	LicenseDeclared       string            `json:"licenseDeclared,omitempty"`
+	LicenseComments       string            `json:"licenseComments,omitempty"`
+	CopyrightText         string            `json:"copyrightText,omitempty"`
+	Summary               string            `json:"summary,omitempty"`
+	Description           string            `json:"description,omitempty"`
+	Comment               string            `json:"comment,omitempty"`
+	ExternalRefs          []ExternalRef     `json:"externalRefs,omitempty"`
+	AttributionTexts      []string          `json:"attributionTexts,omitempty"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose,omitempty"` // Since v2.3
+	ReleaseDate           string            `json:"releaseDate,omitempty"`           // Since v2.3
+	BuiltDate             string            `json:"builtDate,omitempty"`             // Since v2.3
+	ValidUntilDate        string            `json:"validUntilDate,omitempty"`        // Since v2.3
+	Annotations           []Annotation      `json:"annotations,omitempty"`
+}
+
+type VerificationCode struct {
+	Value         string   `json:"packageVerificationCodeValue"`
+	ExcludedFiles []string `json:"packageVerificationCodeExcludedFiles,omitempty"`
+}
+
+type ChecksumAlgorithm string
+
+const (
+	ChecksumAlgorithmSHA1        ChecksumAlgorithm = "SHA1"
+	ChecksumAlgorithmSHA224      ChecksumAlgorithm = "SHA224"
+	ChecksumAlgorithmSHA256      ChecksumAlgorithm = "SHA256"
+	ChecksumAlgorithmSHA384      ChecksumAlgorithm = "SHA384"
+	ChecksumAlgorithmSHA512      ChecksumAlgorithm = "SHA512"
+	ChecksumAlgorithmMD2         ChecksumAlgorithm = "MD2"
+	ChecksumAlgorithmMD4         ChecksumAlgorithm = "MD4"
+	ChecksumAlgorithmMD5         ChecksumAlgorithm = "MD5"
+	ChecksumAlgorithmMD6         ChecksumAlgorithm = "MD6"
+	ChecksumAlgorithmSHA3_256    ChecksumAlgorithm = "SHA3-256"
+	ChecksumAlgorithmSHA3_384    ChecksumAlgorithm = "SHA3-384"
+	ChecksumAlgorithmSHA3_512    ChecksumAlgorithm = "SHA3-512"
+	ChecksumAlgorithmBLAKE2b_256 ChecksumAlgorithm = "BLAKE2b-256"
+	ChecksumAlgorithmBLAKE2b_384 ChecksumAlgorithm = "BLAKE2b-384"
+	ChecksumAlgorithmBLAKE2b_512 ChecksumAlgorithm = "BLAKE2b-512"
+	ChecksumAlgorithmBLAKE3      ChecksumAlgorithm = "BLAKE3"
+	ChecksumAlgorithmADLER32     ChecksumAlgorithm = "ADLER32"
+)
+
+type Checksum struct {
+	Algorithm ChecksumAlgorithm `json:"algorithm"`
+	Value     string            `json:"checksumValue"`
+}
+
+type ExternalRefCategory string
+
+const (
+	ExternalRefCategorySecurity       ExternalRefCategory = "SECURITY"
+	ExternalRefCategoryPackageManager ExternalRefCategory = "PACKAGE-MANAGER"
+	ExternalRefCategoryPersistentID   ExternalRefCategory = "PERSISTENT-ID"
+	ExternalRefCategoryOther          ExternalRefCategory = "OTHER"
+)
+
+type ExternalRef struct {
+	Category ExternalRefCategory `json:"referenceCategory"`
+	Type     string              `json:"referenceType"` // e.g. purl, cpe23Type
+	Locator  string              `json:"referenceLocator"`
+	Comment  string              `json:"comment,omitempty"`
+}
+
+type File struct {
+	SPDXID             string     `json:"SPDXID"`
+	FileName           string     `json:"fileName"`
+	FileTypes          []string   `json:"fileTypes,omitempty"`
+	Checksums          []Checksum `json:"checksums,omitempty"`
+	LicenseConcluded   string     `json:"licenseConcluded,omitempty"`
+	LicenseInfoInFiles []string   `json:"licenseInfoInFiles,omitempty"`
+	CopyrightText      string     `json:"copyrightText,omitempty"`
+	Comment            string     `json:"comment,omitempty"`
+}
+
+type Snippet struct {
+	SPDXID          string `json:"SPDXID"`
+	SnippetFromFile string `json:"snippetFromFile"`
+	Name            string `json:"name,omitempty"`
+}
+
+type RelationshipType string
+
+const (
+	RelationshipDescribes            RelationshipType = "DESCRIBES"
+	RelationshipDescribedBy          RelationshipType = "DESCRIBED_BY"
+	RelationshipContains             RelationshipType = "CONTAINS"
+	RelationshipContainedBy          RelationshipType = "CONTAINED_BY"
+	RelationshipDependsOn            RelationshipType = "DEPENDS_ON"
+	RelationshipDependencyOf         RelationshipType = "DEPENDENCY_OF"
+	RelationshipBuildDependencyOf    RelationshipType = "BUILD_DEPENDENCY_OF"
+	RelationshipDevDependencyOf      RelationshipType = "DEV_DEPENDENCY_OF"
+	RelationshipOptionalDependencyOf RelationshipType = "OPTIONAL_DEPENDENCY_OF"
+	RelationshipProvidedDependencyOf RelationshipType = "PROVIDED_DEPENDENCY_OF"
+	RelationshipRuntimeDependencyOf  RelationshipType = "RUNTIME_DEPENDENCY_OF"
+	RelationshipTestDependencyOf     RelationshipType = "TEST_DEPENDENCY_OF"
+	RelationshipDependencyManifestOf RelationshipType = "DEPENDENCY_MANIFEST_OF"
+	RelationshipGeneratedFrom        RelationshipType = "GENERATED_FROM"
+	RelationshipOther                RelationshipType = "OTHER"
+)
+
+type Relationship struct {
+	Element        string           `json:"spdxElementId"`
+	Type           RelationshipType `json:"relationshipType"`
+	RelatedElement string           `json:"relatedSpdxElement"`
+	Comment        string           `json:"comment,omitempty"`
+}
+
+type Annotation struct {
+	Annotator string `json:"annotator"`
+	Date      string `json:"annotationDate"`
+	Type      string `json:"annotationType"` // REVIEW or OTHER
+	Comment   string `json:"comment"`
+}
+
+// ExtractedLicensingInfo describes a license that is not on the SPDX license list,
+// referred to by a LicenseRef- identifier.
+type ExtractedLicensingInfo struct {
+	LicenseID     string   `json:"licenseId"`
+	ExtractedText string   `json:"extractedText"`
+	Name          string   `json:"name,omitempty"`
+	SeeAlsos      []string `json:"seeAlsos,omitempty"`
+	Comment       string   `json:"comment,omitempty"`
+}
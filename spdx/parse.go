@@ -0,0 +1,426 @@
+package spdx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatJSON     Format = "JSON"
+	FormatTagValue Format = "TAG_VALUE"
+)
+
+// DetectFormat determines the format of a document by its first non-whitespace character.
+func DetectFormat(data []byte) (Format, error) {
+	data = bytes.TrimLeft(data, "\ufeff \t\r\n")
+	if len(data) == 0 {
+		return "", fmt.Errorf("document is empty")
+	}
+
+	switch {
+	case data[0] == '{':
+		return FormatJSON, nil
+	case data[0] == '#' || bytes.HasPrefix(data, []byte("SPDXVersion:")):
+		return FormatTagValue, nil
+	default:
+		return "", fmt.Errorf("document is neither JSON nor tag-value")
+	}
+}
+
+// Parse parses a JSON or tag-value document.
+func Parse(data []byte) (*Document, Format, error) {
+	format, err := DetectFormat(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var doc *Document
+	if format == FormatJSON {
+		doc, err = ParseJSON(data)
+	} else {
+		doc, err = ParseTagValue(data)
+	}
+
+	return doc, format, err
+}
+
+// ParseJSON parses a JSON document.
+func ParseJSON(data []byte) (*Document, error) {
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+type section int
+
+const (
+	sectionDocument section = iota
+	sectionPackage
+	sectionFile
+	sectionSnippet
+	sectionLicense
+	sectionAnnotation
+)
+
+// tagValueParser parses tag-value documents. Tags apply to the most recently started section,
+// e.g. the package started by the last PackageName tag.
+type tagValueParser struct {
+	doc         Document
+	section     section
+	annotations []pendingAnnotation
+}
+
+// pendingAnnotation is an annotation whose element is only known after the whole document has been parsed.
+type pendingAnnotation struct {
+	ref        string
+	annotation Annotation
+}
+
+// ParseTagValue parses a tag-value document.
+func ParseTagValue(data []byte) (*Document, error) {
+	var p tagValueParser
+
+	lines := strings.Split(strings.TrimPrefix(string(data), "\ufeff"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		tag, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected tag and value separated by colon", lineNumber)
+		}
+		value = strings.TrimSpace(value)
+
+		// Multi-line values are enclosed in <text> tags.
+		if strings.HasPrefix(value, "<text>") {
+			text := strings.TrimPrefix(value, "<text>")
+			for !strings.Contains(text, "</text>") {
+				i++
+				if i == len(lines) {
+					return nil, fmt.Errorf("line %d: unterminated <text> value", lineNumber)
+				}
+				text += "\n" + strings.TrimRight(lines[i], "\r")
+			}
+			value = text[:strings.Index(text, "</text>")]
+		}
+
+		if err := p.parse(strings.TrimSpace(tag), value); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+	}
+
+	p.resolveAnnotations()
+	return &p.doc, nil
+}
+
+func (p *tagValueParser) parse(tag, value string) error {
+	switch tag {
+	// Document
+	case "SPDXVersion":
+		p.doc.SPDXVersion = value
+	case "DataLicense":
+		p.doc.DataLicense = value
+	case "DocumentName":
+		p.doc.Name = value
+	case "DocumentNamespace":
+		p.doc.DocumentNamespace = value
+	case "DocumentComment":
+		p.doc.Comment = value
+	case "ExternalDocumentRef":
+		ref, err := parseExternalDocumentRef(value)
+		if err != nil {
+			return err
+		}
+		p.doc.ExternalDocumentRefs = append(p.doc.ExternalDocumentRefs, ref)
+	case "Creator":
+		p.doc.CreationInfo.Creators = append(p.doc.CreationInfo.Creators, value)
+	case "Created":
+		p.doc.CreationInfo.Created = value
+	case "LicenseListVersion":
+		p.doc.CreationInfo.LicenseListVersion = value
+	case "CreatorComment":
+		p.doc.CreationInfo.Comment = value
+	case "SPDXID":
+		switch p.section {
+		case sectionPackage:
+			p.pkg().SPDXID = value
+		case sectionFile:
+			p.file().SPDXID = value
+		case sectionDocument, sectionSnippet, sectionLicense, sectionAnnotation:
+			p.doc.SPDXID = value
+		}
+
+	// Package
+	case "PackageName":
+		p.section = sectionPackage
+		p.doc.Packages = append(p.doc.Packages, Package{Name: value})
+	case "PackageVersion", "PackageFileName", "PackageSupplier", "PackageOriginator", "PackageDownloadLocation",
+		"FilesAnalyzed", "PackageVerificationCode", "PackageChecksum", "PackageHomePage", "PackageSourceInfo",
+		"PackageLicenseConcluded", "PackageLicenseInfoFromFiles", "PackageLicenseDeclared", "PackageLicenseComments",
+		"PackageCopyrightText", "PackageSummary", "PackageDescription", "PackageComment", "ExternalRef",
+		"ExternalRefComment", "PackageAttributionText", "PrimaryPackagePurpose", "ReleaseDate", "BuiltDate", "ValidUntilDate":
+		if p.section != sectionPackage {
+			return fmt.Errorf("%s outside of package", tag)
+		}
+		return p.parsePackage(p.pkg(), tag, value)
+
+	// File
+	case "FileName":
+		p.section = sectionFile
+		p.doc.Files = append(p.doc.Files, File{FileName: value})
+	case "FileType", "FileChecksum", "LicenseConcluded", "LicenseInfoInFile", "FileCopyrightText", "FileComment":
+		if p.section != sectionFile {
+			return fmt.Errorf("%s outside of file", tag)
+		}
+		return p.parseFile(p.file(), tag, value)
+
+	// Snippet
+	case "SnippetSPDXID":
+		p.section = sectionSnippet
+		p.doc.Snippets = append(p.doc.Snippets, Snippet{SPDXID: value})
+	case "SnippetFromFileSPDXID", "SnippetName":
+		if p.section != sectionSnippet {
+			return fmt.Errorf("%s outside of snippet", tag)
+		}
+		snippet := &p.doc.Snippets[len(p.doc.Snippets)-1]
+		if tag == "SnippetName" {
+			snippet.Name = value
+		} else {
+			snippet.SnippetFromFile = value
+		}
+
+	// Other licensing information
+	case "LicenseID":
+		p.section = sectionLicense
+		p.doc.ExtractedLicensingInfos = append(p.doc.ExtractedLicensingInfos, ExtractedLicensingInfo{LicenseID: value})
+	case "ExtractedText", "LicenseName", "LicenseCrossReference", "LicenseComment":
+		if p.section != sectionLicense {
+			return fmt.Errorf("%s outside of license", tag)
+		}
+		license := &p.doc.ExtractedLicensingInfos[len(p.doc.ExtractedLicensingInfos)-1]
+		switch tag {
+		case "ExtractedText":
+			license.ExtractedText = value
+		case "LicenseName":
+			license.Name = value
+		case "LicenseCrossReference":
+			license.SeeAlsos = append(license.SeeAlsos, value)
+		case "LicenseComment":
+			license.Comment = value
+		}
+
+	// Relationship
+	case "Relationship":
+		fields := strings.Fields(value)
+		if len(fields) != 3 {
+			return fmt.Errorf("invalid relationship %q", value)
+		}
+		p.doc.Relationships = append(p.doc.Relationships, Relationship{
+			Element:        fields[0],
+			Type:           RelationshipType(fields[1]),
+			RelatedElement: fields[2],
+		})
+	case "RelationshipComment":
+		if len(p.doc.Relationships) == 0 {
+			return fmt.Errorf("%s without relationship", tag)
+		}
+		p.doc.Relationships[len(p.doc.Relationships)-1].Comment = value
+
+	// Annotation
+	case "Annotator":
+		p.section = sectionAnnotation
+		p.annotations = append(p.annotations, pendingAnnotation{annotation: Annotation{Annotator: value}})
+	case "AnnotationDate", "AnnotationType", "SPDXREF", "AnnotationComment":
+		if p.section != sectionAnnotation {
+			return fmt.Errorf("%s outside of annotation", tag)
+		}
+		pending := &p.annotations[len(p.annotations)-1]
+		switch tag {
+		case "AnnotationDate":
+			pending.annotation.Date = value
+		case "AnnotationType":
+			pending.annotation.Type = value
+		case "SPDXREF":
+			pending.ref = value
+		case "AnnotationComment":
+			pending.annotation.Comment = value
+		}
+	}
+
+	return nil
+}
+
+func (p *tagValueParser) parsePackage(pkg *Package, tag, value string) error {
+	switch tag {
+	case "PackageVersion":
+		pkg.VersionInfo = value
+	case "PackageFileName":
+		pkg.PackageFileName = value
+	case "PackageSupplier":
+		pkg.Supplier = value
+	case "PackageOriginator":
+		pkg.Originator = value
+	case "PackageDownloadLocation":
+		pkg.DownloadLocation = value
+	case "FilesAnalyzed":
+		filesAnalyzed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q", tag, value)
+		}
+		pkg.FilesAnalyzed = &filesAnalyzed
+	case "PackageVerificationCode":
+		code, excludes, _ := strings.Cut(value, "(excludes:")
+		pkg.VerificationCode = &VerificationCode{Value: strings.TrimSpace(code)}
+		if excludes = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(excludes), ")")); excludes != "" {
+			pkg.VerificationCode.ExcludedFiles = []string{excludes}
+		}
+	case "PackageChecksum":
+		checksum, err := parseChecksum(value)
+		if err != nil {
+			return err
+		}
+		pkg.Checksums = append(pkg.Checksums, checksum)
+	case "PackageHomePage":
+		pkg.Homepage = value
+	case "PackageSourceInfo":
+		pkg.SourceInfo = value
+	case "PackageLicenseConcluded":
+		pkg.LicenseConcluded = value
+	case "PackageLicenseInfoFromFiles":
+		pkg.LicenseInfoFromFiles = append(pkg.LicenseInfoFromFiles, value)
+	case "PackageLicenseDeclared":
+		pkg.LicenseDeclared = value
+	case "PackageLicenseComments":
+		pkg.LicenseComments = value
+	case "PackageCopyrightText":
+		pkg.CopyrightText = value
+	case "PackageSummary":
+		pkg.Summary = value
+	case "PackageDescription":
+		pkg.Description = value
+	case "PackageComment":
+		pkg.Comment = value
+	case "ExternalRef":
+		fields := strings.Fields(value)
+		if len(fields) != 3 {
+			return fmt.Errorf("invalid external reference %q", value)
+		}
+		pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+			Category: ExternalRefCategory(fields[0]),
+			Type:     fields[1],
+			Locator:  fields[2],
+		})
+	case "ExternalRefComment":
+		if len(pkg.ExternalRefs) == 0 {
+			return fmt.Errorf("%s without external reference", tag)
+		}
+		pkg.ExternalRefs[len(pkg.ExternalRefs)-1].Comment = value
+	case "PackageAttributionText":
+		pkg.AttributionTexts = append(pkg.AttributionTexts, value)
+	case "PrimaryPackagePurpose":
+		pkg.PrimaryPackagePurpose = value
+	case "ReleaseDate":
+		pkg.ReleaseDate = value
+	case "BuiltDate":
+		pkg.BuiltDate = value
+	case "ValidUntilDate":
+		pkg.ValidUntilDate = value
+	}
+
+	return nil
+}
+
+func (p *tagValueParser) parseFile(file *File, tag, value string) error {
+	switch tag {
+	case "FileType":
+		file.FileTypes = append(file.FileTypes, value)
+	case "FileChecksum":
+		checksum, err := parseChecksum(value)
+		if err != nil {
+			return err
+		}
+		file.Checksums = append(file.Checksums, checksum)
+	case "LicenseConcluded":
+		file.LicenseConcluded = value
+	case "LicenseInfoInFile":
+		file.LicenseInfoInFiles = append(file.LicenseInfoInFiles, value)
+	case "FileCopyrightText":
+		file.CopyrightText = value
+	case "FileComment":
+		file.Comment = value
+	}
+
+	return nil
+}
+
+func (p *tagValueParser) pkg() *Package {
+	return &p.doc.Packages[len(p.doc.Packages)-1]
+}
+
+func (p *tagValueParser) file() *File {
+	return &p.doc.Files[len(p.doc.Files)-1]
+}
+
+// resolveAnnotations attaches annotations to the packages they refer to.
+// Annotations of all other elements are attached to the document.
+func (p *tagValueParser) resolveAnnotations() {
+	for _, pending := range p.annotations {
+		i := -1
+		for j := range p.doc.Packages {
+			if p.doc.Packages[j].SPDXID == pending.ref {
+				i = j
+				break
+			}
+		}
+
+		if i >= 0 {
+			p.doc.Packages[i].Annotations = append(p.doc.Packages[i].Annotations, pending.annotation)
+		} else {
+			p.doc.Annotations = append(p.doc.Annotations, pending.annotation)
+		}
+	}
+}
+
+// parseChecksum parses checksums of the form "SHA1: d6a770ba38583ed4bb4525bd96e50461655d2758".
+func parseChecksum(value string) (Checksum, error) {
+	algorithm, checksum, ok := strings.Cut(value, ":")
+	if !ok {
+		return Checksum{}, fmt.Errorf("invalid checksum %q", value)
+	}
+
+	return Checksum{
+		Algorithm: ChecksumAlgorithm(strings.TrimSpace(algorithm)),
+		Value:     strings.TrimSpace(checksum),
+	}, nil
+}
+
+// parseExternalDocumentRef parses references of the form
+// "DocumentRef-spdx-tool-1.2 http://spdx.org/spdxdocs/spdx-tools-v1.2-3F25 SHA1: d6a770ba38583ed4bb4525bd96e50461655d2759".
+func parseExternalDocumentRef(value string) (ExternalDocumentRef, error) {
+	fields := strings.SplitN(value, " ", 3)
+	if len(fields) != 3 {
+		return ExternalDocumentRef{}, fmt.Errorf("invalid external document reference %q", value)
+	}
+
+	checksum, err := parseChecksum(fields[2])
+	if err != nil {
+		return ExternalDocumentRef{}, err
+	}
+
+	return ExternalDocumentRef{
+		ExternalDocumentID: fields[0],
+		SPDXDocument:       fields[1],
+		Checksum:           checksum,
+	}, nil
+}
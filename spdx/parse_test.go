@@ -0,0 +1,67 @@
+package spdx
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	jsonDoc, format, err := Parse(readFile(t, "./testdata/document.json"))
+	require.NoError(t, err)
+	require.Equal(t, FormatJSON, format)
+
+	require.Equal(t, "SPDX-2.3", jsonDoc.SPDXVersion)
+	require.Len(t, jsonDoc.Packages, 4)
+	require.Equal(t, []Checksum{
+		{Algorithm: ChecksumAlgorithmSHA1, Value: "679591C564C3BFFAAE8454CF0B3DF370C3D6911C"},
+		{Algorithm: ChecksumAlgorithmMD2, Value: "b5e5c29f7d0da57ac52d0d6e3f1d1ad1"},
+	}, jsonDoc.Packages[1].Checksums)
+	require.Equal(t, "Internal helpers.\nNot published.", jsonDoc.Packages[3].Description)
+
+	tagValueDoc, format, err := Parse(readFile(t, "./testdata/document.spdx"))
+	require.NoError(t, err)
+	require.Equal(t, FormatTagValue, format)
+
+	require.Equal(t, jsonDoc, tagValueDoc)
+}
+
+func TestParseTagValue(t *testing.T) {
+	t.Run("Annotations", func(t *testing.T) {
+		doc, err := ParseTagValue([]byte(`SPDXVersion: SPDX-2.3
+PackageName: foo
+SPDXID: SPDXRef-Package-foo
+Annotator: Person: Jane Doe
+AnnotationDate: 2024-01-01T00:00:00Z
+AnnotationType: REVIEW
+SPDXREF: SPDXRef-Package-foo
+AnnotationComment: <text>Looks good.</text>
+Annotator: Tool: scanner
+AnnotationType: OTHER
+SPDXREF: SPDXRef-DOCUMENT
+`))
+		require.NoError(t, err)
+		require.Equal(t, []Annotation{{Annotator: "Person: Jane Doe", Date: "2024-01-01T00:00:00Z", Type: "REVIEW", Comment: "Looks good."}}, doc.Packages[0].Annotations)
+		require.Equal(t, []Annotation{{Annotator: "Tool: scanner", Type: "OTHER"}}, doc.Annotations)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		for input, message := range map[string]string{
+			"SPDXVersion: SPDX-2.3\nPackageVersion: 1.0.0":               "line 2: PackageVersion outside of package",
+			"SPDXVersion: SPDX-2.3\nRelationship: SPDXRef-DOCUMENT":      `line 2: invalid relationship "SPDXRef-DOCUMENT"`,
+			"SPDXVersion: SPDX-2.3\nDocumentComment: <text>unterminated": "line 2: unterminated <text> value",
+			"SPDXVersion: SPDX-2.3\nfoo":                                 "line 2: expected tag and value separated by colon",
+		} {
+			_, err := ParseTagValue([]byte(input))
+			require.EqualError(t, err, message)
+		}
+	})
+}
+
+func readFile(t *testing.T, path string) []byte {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	return content
+}
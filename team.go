@@ -3,6 +3,7 @@ package dtrack
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -53,6 +54,13 @@ func (ts TeamService) GetAll(ctx context.Context, po PageOptions) (p Page[Team],
 	return
 }
 
+// All returns an iterator over all items that GetAll would return across all pages.
+func (ts TeamService) All(ctx context.Context, options ...IterOption) iter.Seq2[Team, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Team], error) {
+		return ts.GetAll(ctx, po)
+	}, options...)
+}
+
 func (ts TeamService) GenerateAPIKey(ctx context.Context, teamUUID uuid.UUID) (key APIKey, err error) {
 	req, err := ts.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("/api/v1/team/%s/key", teamUUID))
 	if err != nil {
@@ -0,0 +1,238 @@
+package dtrack
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/futurice/dependency-track-client-go"
+
+const contextKeyOperation contextKey = "operation"
+
+// Attributes recorded on spans and metrics, in addition to the OpenTelemetry semantic conventions.
+const (
+	attributeService       = attribute.Key("dtrack.service")
+	attributeOperation     = attribute.Key("dtrack.operation")
+	attributeProjectUUID   = attribute.Key("dtrack.project.uuid")
+	attributeComponentUUID = attribute.Key("dtrack.component.uuid")
+	attributeRetryCount    = attribute.Key("dtrack.retry_count")
+)
+
+// WithTracerProvider sets the OpenTelemetry TracerProvider used to create spans.
+// A span is created for every service call, e.g. dtrack.Project.Lookup. Methods making several calls,
+// e.g. dtrack.BOM.UploadAndWait, create a span that is the parent of the spans of those calls.
+// Defaults to the global TracerProvider.
+func WithTracerProvider(provider trace.TracerProvider) ClientOption {
+	return func(c *Client) error {
+		c.tracerProvider = provider
+		return nil
+	}
+}
+
+// WithMeterProvider sets the OpenTelemetry MeterProvider used to record request metrics.
+// Defaults to the global MeterProvider.
+func WithMeterProvider(provider metric.MeterProvider) ClientOption {
+	return func(c *Client) error {
+		c.meterProvider = provider
+		return nil
+	}
+}
+
+type telemetry struct {
+	tracer          trace.Tracer
+	requestDuration metric.Float64Histogram
+}
+
+func newTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) (*telemetry, error) {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+
+	requestDuration, err := meterProvider.Meter(instrumentationName).Float64Histogram("dtrack.client.request.duration",
+		metric.WithDescription("Duration of Dependency-Track API calls, including retries."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &telemetry{
+		tracer:          tracerProvider.Tracer(instrumentationName),
+		requestDuration: requestDuration,
+	}, nil
+}
+
+// operation identifies the logical operation a request is made for, e.g. Project.Lookup.
+type operation struct {
+	service string
+	method  string
+}
+
+func (o operation) String() string {
+	if o.service == "" {
+		return "dtrack.request"
+	}
+
+	return "dtrack." + o.service + "." + o.method
+}
+
+// namedOperation parses the name of an operation, e.g. Finding.GetAllPortfolio.
+func namedOperation(name string) operation {
+	service, method, _ := strings.Cut(name, ".")
+	return operation{service: service, method: method}
+}
+
+// callerOperation determines the operation from the name of the service method
+// that is skip frames above the caller of callerOperation.
+// It is used by newRequest for requests made directly by service methods,
+// requests made by helpers shared between methods are named using withOperationName.
+func callerOperation(skip int) operation {
+	pcs := make([]uintptr, 1)
+	if runtime.Callers(skip+2, pcs) == 0 {
+		return operation{}
+	}
+
+	frame, _ := runtime.CallersFrames(pcs).Next()
+
+	// e.g. github.com/futurice/dependency-track-client-go.ProjectService.Lookup
+	name := frame.Function[strings.LastIndex(frame.Function, "/")+1:]
+	parts := strings.Split(name, ".")
+	if len(parts) < 3 || !strings.HasSuffix(parts[1], "Service") {
+		return operation{}
+	}
+
+	return operation{
+		service: strings.TrimSuffix(parts[1], "Service"),
+		method:  parts[2],
+	}
+}
+
+func withOperation(ctx context.Context, op operation) context.Context {
+	return context.WithValue(ctx, contextKeyOperation, op)
+}
+
+func requestOperation(req *http.Request) operation {
+	op, _ := req.Context().Value(contextKeyOperation).(operation)
+	return op
+}
+
+// withOperationName attributes a request to the named operation, e.g. Finding.GetAllPortfolio.
+func withOperationName(name string) requestOption {
+	return func(req *http.Request) error {
+		*req = *req.WithContext(withOperation(req.Context(), namedOperation(name)))
+		return nil
+	}
+}
+
+// startSpan starts a span for a service method that makes several requests, e.g. BOM.UploadAndWait,
+// which becomes the parent of the spans of those requests.
+// The returned function must be called with the error the method returns.
+func (c Client) startSpan(ctx context.Context, name string) (context.Context, func(err error)) {
+	op := namedOperation(name)
+
+	ctx, span := c.telemetry.tracer.Start(ctx, op.String(),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			attributeService.String(op.service),
+			attributeOperation.String(op.method)))
+
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// startOperation starts a span for the operation req belongs to.
+// The returned function must be called once the operation completed.
+func (c Client) startOperation(req *http.Request) (*http.Request, func(res *http.Response, attempts int, err error)) {
+	op := requestOperation(req)
+	start := time.Now()
+
+	attrs := []attribute.KeyValue{
+		attributeService.String(op.service),
+		attributeOperation.String(op.method),
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(req.URL.Redacted()),
+	}
+	attrs = append(attrs, uuidAttributes(req)...)
+
+	ctx, span := c.telemetry.tracer.Start(req.Context(), op.String(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+
+	return req.WithContext(ctx), func(res *http.Response, attempts int, err error) {
+		metricAttrs := []attribute.KeyValue{
+			attributeService.String(op.service),
+			attributeOperation.String(op.method),
+		}
+
+		if attempts > 1 {
+			span.SetAttributes(attributeRetryCount.Int(attempts - 1))
+		}
+		if res != nil {
+			span.SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+			metricAttrs = append(metricAttrs, semconv.HTTPResponseStatusCode(res.StatusCode))
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			metricAttrs = append(metricAttrs, semconv.ErrorTypeKey.String(errorType(res)))
+		}
+		span.End()
+
+		c.telemetry.requestDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(metricAttrs...))
+	}
+}
+
+// uuidAttributes extracts the UUIDs of projects and components from the path and query of req.
+func uuidAttributes(req *http.Request) (attrs []attribute.KeyValue) {
+	keys := map[string]attribute.Key{
+		"project":   attributeProjectUUID,
+		"component": attributeComponentUUID,
+	}
+
+	segments := strings.Split(req.URL.Path, "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if key, ok := keys[segments[i]]; ok {
+			if _, err := uuid.Parse(segments[i+1]); err == nil {
+				attrs = append(attrs, key.String(segments[i+1]))
+			}
+		}
+	}
+
+	query := req.URL.Query()
+	for param, key := range keys {
+		if value := query.Get(param); value != "" {
+			if _, err := uuid.Parse(value); err == nil {
+				attrs = append(attrs, key.String(value))
+			}
+		}
+	}
+
+	return
+}
+
+func errorType(res *http.Response) string {
+	if res != nil && res.StatusCode >= 400 {
+		return strconv.Itoa(res.StatusCode)
+	}
+
+	return "request_error"
+}
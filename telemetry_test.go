@@ -0,0 +1,106 @@
+package dtrack
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTelemetry(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	metricReader := sdkmetric.NewManualReader()
+
+	client, err := NewClient("http://localhost",
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))),
+		WithRetryPolicy(RetryPolicy{InitialBackoff: time.Millisecond}))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	projectUUID := uuid.MustParse("6fb1820f-5280-4577-ac51-40124aabe307")
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/lookup",
+		httpmock.NewStringResponder(http.StatusOK, `{"name":"acme-app"}`))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/component/project/"+projectUUID.String(),
+		httpmock.NewStringResponder(http.StatusServiceUnavailable, "").
+			Then(httpmock.NewStringResponder(http.StatusNotFound, "")))
+
+	_, err = client.Project.Lookup(context.TODO(), "acme-app", "1.0.0")
+	require.NoError(t, err)
+
+	_, err = client.Component.GetAll(context.TODO(), projectUUID, PageOptions{})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 2)
+
+	require.Equal(t, "dtrack.Project.Lookup", spans[0].Name())
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.Contains(t, spans[0].Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+
+	require.Equal(t, "dtrack.Component.GetAll", spans[1].Name())
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+	require.Contains(t, spans[1].Attributes(), attribute.String("dtrack.project.uuid", projectUUID.String()))
+	require.Contains(t, spans[1].Attributes(), attribute.Int("dtrack.retry_count", 1))
+	require.Contains(t, spans[1].Attributes(), attribute.Int("http.response.status_code", http.StatusNotFound))
+
+	var metrics metricdata.ResourceMetrics
+	require.NoError(t, metricReader.Collect(context.TODO(), &metrics))
+	require.Len(t, metrics.ScopeMetrics, 1)
+	require.Len(t, metrics.ScopeMetrics[0].Metrics, 1)
+	require.Equal(t, "dtrack.client.request.duration", metrics.ScopeMetrics[0].Metrics[0].Name)
+
+	histogram, ok := metrics.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, histogram.DataPoints, 2)
+}
+
+func TestTelemetry_ParentSpan(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+
+	client, err := NewClient("http://localhost",
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))))
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+		httpmock.NewStringResponder(http.StatusOK, `{"version":"4.11.0"}`))
+	httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/bom",
+		httpmock.NewStringResponder(http.StatusOK, `{"token":"foo"}`))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/event/token/foo",
+		httpmock.NewStringResponder(http.StatusOK, `{"processing":false}`))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/lookup?name=acme-app&version=1.0.0",
+		httpmock.NewStringResponder(http.StatusNotFound, ""))
+
+	_, err = client.BOM.UploadAndWait(context.TODO(), BOMUploadRequest{ProjectName: "acme-app", ProjectVersion: "1.0.0", BOM: "Ym9t"},
+		UploadAndWaitOptions{Wait: WaitOptions{PollInterval: time.Millisecond}})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	names := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range spanRecorder.Ended() {
+		names[span.Name()] = span
+	}
+
+	parent, ok := names["dtrack.BOM.UploadAndWait"]
+	require.True(t, ok)
+	require.Equal(t, codes.Error, parent.Status().Code)
+	require.Equal(t, parent.SpanContext().SpanID(), names["dtrack.BOM.Upload"].Parent().SpanID())
+	require.Equal(t, parent.SpanContext().SpanID(), names["dtrack.BOM.WaitForProcessing"].Parent().SpanID())
+	require.Equal(t, parent.SpanContext().SpanID(), names["dtrack.Project.Lookup"].Parent().SpanID())
+	require.Equal(t, names["dtrack.BOM.WaitForProcessing"].SpanContext().SpanID(), names["dtrack.Event.IsBeingProcessed"].Parent().SpanID())
+}
@@ -1,6 +1,15 @@
 package dtrack
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sync"
+)
+
+// DefaultPageSize is the page size used when iterating over paginated API resources.
+const DefaultPageSize = 50
 
 // FetchAll is a convenience function to retrieve all items of a paginated API resource.
 func FetchAll[T any](pageFetchFunc func(po PageOptions) (Page[T], error)) (items []T, err error) {
@@ -14,7 +23,7 @@ func FetchAll[T any](pageFetchFunc func(po PageOptions) (Page[T], error)) (items
 
 // ForEach is a convenience function to perform an action on every item of a paginated API resource.
 func ForEach[T any](pageFetchFunc func(po PageOptions) (Page[T], error), handlerFunc func(item T) error) (err error) {
-	const pageSize = 50
+	const pageSize = DefaultPageSize
 
 	var (
 		page       Page[T]
@@ -48,3 +57,190 @@ func ForEach[T any](pageFetchFunc func(po PageOptions) (Page[T], error), handler
 
 	return
 }
+
+// IterOption configures iterators returned by Iterate and the All methods of services.
+type IterOption func(*iterConfig)
+
+type iterConfig struct {
+	pageSize int
+}
+
+// WithPageSize overrides the number of items fetched per page.
+func WithPageSize(pageSize int) IterOption {
+	return func(ic *iterConfig) {
+		if pageSize > 0 {
+			ic.pageSize = pageSize
+		}
+	}
+}
+
+// Iterate returns an iterator over all items of a paginated API resource.
+// Pages are fetched lazily, as the iterator is advanced. Iteration stops at the first error,
+// which is yielded together with the zero value of T. Errors include cancellation of ctx.
+func Iterate[T any](ctx context.Context, pageFetchFunc func(ctx context.Context, po PageOptions) (Page[T], error), options ...IterOption) iter.Seq2[T, error] {
+	config := iterConfig{pageSize: DefaultPageSize}
+	for _, option := range options {
+		option(&config)
+	}
+
+	return func(yield func(T, error) bool) {
+		var zero T
+		itemsSeen := 0
+
+		for pageNumber := 1; ; pageNumber++ {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			page, err := pageFetchFunc(ctx, PageOptions{
+				PageNumber: pageNumber,
+				PageSize:   config.pageSize,
+			})
+			if err != nil {
+				yield(zero, fmt.Errorf("failed to fetch page %d: %w", pageNumber, err))
+				return
+			}
+
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			itemsSeen += len(page.Items)
+			if len(page.Items) == 0 || itemsSeen >= page.TotalCount {
+				return
+			}
+		}
+	}
+}
+
+// ErrTotalCountChanged is returned by FetchAllParallel when the number of items
+// in a collection keeps changing while it is being fetched.
+var ErrTotalCountChanged = errors.New("total count changed while fetching")
+
+// maxParallelScans is the number of times FetchAllParallel scans a collection
+// before giving up because its total count keeps changing.
+const maxParallelScans = 3
+
+// FetchAllParallel is like FetchAll, but fetches pages concurrently using up to workers goroutines.
+//
+// The first page is fetched on its own to learn the total number of items and the effective page size,
+// after which the remaining pages are distributed among the workers. Items are returned in the same order as FetchAll would return them.
+// The first error cancels all other workers. If the total number of items changes while the collection
+// is being fetched, the collection is fetched again, so that no items are missed.
+func FetchAllParallel[T any](ctx context.Context, workers int, pageFetchFunc func(ctx context.Context, po PageOptions) (Page[T], error), options ...IterOption) ([]T, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	config := iterConfig{pageSize: DefaultPageSize}
+	for _, option := range options {
+		option(&config)
+	}
+
+	for scan := 0; scan < maxParallelScans; scan++ {
+		items, complete, err := fetchAllParallel(ctx, workers, config.pageSize, pageFetchFunc)
+		if err != nil {
+			return nil, err
+		}
+		if complete {
+			return items, nil
+		}
+	}
+
+	return nil, ErrTotalCountChanged
+}
+
+// fetchAllParallel performs a single scan of a paginated collection.
+// complete is false when the total count of the collection changed during the scan.
+func fetchAllParallel[T any](ctx context.Context, workers, pageSize int, pageFetchFunc func(ctx context.Context, po PageOptions) (Page[T], error)) (items []T, complete bool, err error) {
+	firstPage, err := pageFetchFunc(ctx, PageOptions{PageNumber: 1, PageSize: pageSize})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch page 1: %w", err)
+	}
+
+	totalCount := firstPage.TotalCount
+	if len(firstPage.Items) == 0 || len(firstPage.Items) >= totalCount {
+		return firstPage.Items, true, nil
+	}
+
+	// Servers cap the page size, so the number of pages follows from the items actually returned.
+	itemsPerPage := len(firstPage.Items)
+	pageCount := (totalCount + itemsPerPage - 1) / itemsPerPage
+	pages := make([][]T, pageCount)
+	pages[0] = firstPage.Items
+
+	pageNumbers := make(chan int, pageCount-1)
+	for pageNumber := 2; pageNumber <= pageCount; pageNumber++ {
+		pageNumbers <- pageNumber
+	}
+	close(pageNumbers)
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		mutex    sync.Mutex
+		changed  bool
+	)
+
+	if workers > pageCount-1 {
+		workers = pageCount - 1
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for pageNumber := range pageNumbers {
+				if workerCtx.Err() != nil {
+					return
+				}
+
+				page, err := pageFetchFunc(workerCtx, PageOptions{PageNumber: pageNumber, PageSize: pageSize})
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("failed to fetch page %d: %w", pageNumber, err)
+						cancel()
+					})
+					return
+				}
+
+				mutex.Lock()
+				pages[pageNumber-1] = page.Items
+				if page.TotalCount != totalCount {
+					changed = true
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, false, firstErr
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, false, err
+	}
+	if changed {
+		return nil, false, nil
+	}
+
+	items = make([]T, 0, totalCount)
+	for _, page := range pages {
+		items = append(items, page...)
+	}
+	if len(items) != totalCount {
+		return nil, false, nil
+	}
+
+	return items, true, nil
+}
@@ -1,7 +1,9 @@
 package dtrack
 
 import (
+	"context"
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -103,3 +105,208 @@ func TestForEach_HandlerFuncErr(t *testing.T) {
 		t.Errorf("expected error from handlerFunc but got nil")
 	}
 }
+
+func TestIterate(t *testing.T) {
+	var (
+		wantItems     []int
+		gotItems      []int
+		gotPageSizes  []int
+		pageFetchFunc = func(ctx context.Context, po PageOptions) (p Page[int], err error) {
+			gotPageSizes = append(gotPageSizes, po.PageSize)
+			for i := 0; i < po.PageSize; i++ {
+				idx := (po.PageSize * (po.PageNumber - 1)) + i
+				if idx >= len(wantItems) {
+					break
+				}
+				p.Items = append(p.Items, wantItems[idx])
+			}
+			p.TotalCount = len(wantItems)
+			return p, nil
+		}
+	)
+	for i := 0; i < 468; i++ {
+		wantItems = append(wantItems, i)
+	}
+
+	for item, err := range Iterate(context.Background(), pageFetchFunc, WithPageSize(100)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		gotItems = append(gotItems, item)
+	}
+	if diff := cmp.Diff(wantItems, gotItems); diff != "" {
+		t.Errorf("unexpected items:\n%s", diff)
+	}
+	if diff := cmp.Diff([]int{100, 100, 100, 100, 100}, gotPageSizes); diff != "" {
+		t.Errorf("unexpected page sizes:\n%s", diff)
+	}
+
+	gotPageSizes = nil
+	for item := range Iterate(context.Background(), pageFetchFunc) {
+		if item == 10 {
+			break
+		}
+	}
+	if len(gotPageSizes) != 1 {
+		t.Errorf("expected iteration to stop after first page, but fetched %d pages", len(gotPageSizes))
+	}
+}
+
+func TestIterate_PageFetchFuncErr(t *testing.T) {
+	var testErr = errors.New("test error")
+	for _, err := range Iterate(context.Background(),
+		func(ctx context.Context, po PageOptions) (p Page[int], err error) {
+			return p, testErr
+		},
+	) {
+		if !errors.Is(err, testErr) {
+			t.Errorf("expected error from pageFetchFunc but got %v", err)
+		}
+	}
+}
+
+func TestIterate_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var gotErr error
+	for item, err := range Iterate(ctx,
+		func(ctx context.Context, po PageOptions) (p Page[int], err error) {
+			p.Items = []int{po.PageNumber}
+			p.TotalCount = 100
+			return p, nil
+		},
+	) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		if item == 2 {
+			cancel()
+		}
+	}
+	if !errors.Is(gotErr, context.Canceled) {
+		t.Errorf("expected context.Canceled but got %v", gotErr)
+	}
+}
+
+func TestFetchAllParallel(t *testing.T) {
+	var wantItems []int
+	for i := 0; i < 468; i++ {
+		wantItems = append(wantItems, i)
+	}
+
+	gotItems, err := FetchAllParallel(context.Background(), 4,
+		func(ctx context.Context, po PageOptions) (p Page[int], err error) {
+			for i := 0; i < po.PageSize; i++ {
+				idx := (po.PageSize * (po.PageNumber - 1)) + i
+				if idx >= len(wantItems) {
+					break
+				}
+				p.Items = append(p.Items, wantItems[idx])
+			}
+			p.TotalCount = len(wantItems)
+			return p, nil
+		},
+		WithPageSize(25),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(wantItems, gotItems); diff != "" {
+		t.Errorf("unexpected items:\n%s", diff)
+	}
+}
+
+func TestFetchAllParallel_PageSizeCapped(t *testing.T) {
+	const maxPageSize = 100
+
+	var wantItems []int
+	for i := 0; i < 250; i++ {
+		wantItems = append(wantItems, i)
+	}
+
+	gotItems, err := FetchAllParallel(context.Background(), 4,
+		func(ctx context.Context, po PageOptions) (p Page[int], err error) {
+			pageSize := min(po.PageSize, maxPageSize)
+			for i := 0; i < pageSize; i++ {
+				idx := (pageSize * (po.PageNumber - 1)) + i
+				if idx >= len(wantItems) {
+					break
+				}
+				p.Items = append(p.Items, wantItems[idx])
+			}
+			p.TotalCount = len(wantItems)
+			return p, nil
+		},
+		WithPageSize(500),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(wantItems, gotItems); diff != "" {
+		t.Errorf("unexpected items:\n%s", diff)
+	}
+}
+
+func TestFetchAllParallel_PageFetchFuncErr(t *testing.T) {
+	var testErr = errors.New("test error")
+	if _, err := FetchAllParallel(context.Background(), 4,
+		func(ctx context.Context, po PageOptions) (p Page[int], err error) {
+			if po.PageNumber == 3 {
+				return p, testErr
+			}
+			p.Items = make([]int, po.PageSize)
+			p.TotalCount = 10 * po.PageSize
+			return p, nil
+		},
+	); !errors.Is(err, testErr) {
+		t.Errorf("expected error from pageFetchFunc but got %v", err)
+	}
+}
+
+func TestFetchAllParallel_TotalCountChanged(t *testing.T) {
+	var (
+		mutex      sync.Mutex
+		firstCalls int
+		wantItems  []int
+	)
+	for i := 0; i < 120; i++ {
+		wantItems = append(wantItems, i)
+	}
+
+	gotItems, err := FetchAllParallel(context.Background(), 2,
+		func(ctx context.Context, po PageOptions) (p Page[int], err error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			items := wantItems
+			if po.PageNumber == 1 {
+				firstCalls++
+			}
+			if firstCalls == 1 && po.PageNumber > 1 {
+				// Simulate an item being added after the first page was fetched.
+				items = append(items, len(items))
+			}
+
+			for i := 0; i < po.PageSize; i++ {
+				idx := (po.PageSize * (po.PageNumber - 1)) + i
+				if idx >= len(items) {
+					break
+				}
+				p.Items = append(p.Items, items[idx])
+			}
+			p.TotalCount = len(items)
+			return p, nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if firstCalls != 2 {
+		t.Errorf("expected collection to be scanned twice, but was scanned %d times", firstCalls)
+	}
+	if diff := cmp.Diff(wantItems, gotItems); diff != "" {
+		t.Errorf("unexpected items:\n%s", diff)
+	}
+}
@@ -0,0 +1,73 @@
+package dtrack
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/futurice/dependency-track-client-go/cyclonedx"
+)
+
+// ErrInvalidBOM is returned when a BOM fails validation before being uploaded.
+// Use errors.As with *BOMValidationError to access the diagnostics.
+var ErrInvalidBOM = errors.New("invalid bom")
+
+// BOMValidationError is returned when a BOM fails validation before being uploaded.
+type BOMValidationError struct {
+	Diagnostics []cyclonedx.Diagnostic
+}
+
+func (e BOMValidationError) Error() string {
+	var (
+		errs  int
+		first cyclonedx.Diagnostic
+	)
+	for _, d := range e.Diagnostics {
+		if d.Severity == cyclonedx.SeverityError {
+			if errs == 0 {
+				first = d
+			}
+			errs++
+		}
+	}
+
+	return fmt.Sprintf("bom is invalid: %d error(s), first: %s", errs, first)
+}
+
+func (e BOMValidationError) Is(target error) bool {
+	return target == ErrInvalidBOM
+}
+
+// WithBOMValidation enables validation of BOMs before they are uploaded using
+// BOMService.Upload or BOMService.PostBom. BOMs with errors are refused with
+// a BOMValidationError instead of being sent to the server. See cyclonedx.Validate.
+//
+// Streamed uploads are not validated, as that would require buffering the BOM.
+func WithBOMValidation() ClientOption {
+	return func(c *Client) error {
+		c.validateBOMs = true
+		return nil
+	}
+}
+
+// validateBOM validates bom if enabled via WithBOMValidation.
+// When encoded is set, bom is expected to be base64 encoded.
+func (c Client) validateBOM(bom string, encoded bool) error {
+	if !c.validateBOMs {
+		return nil
+	}
+
+	content := []byte(bom)
+	if encoded {
+		var err error
+		if content, err = base64.StdEncoding.DecodeString(bom); err != nil {
+			return fmt.Errorf("failed to decode bom: %w", err)
+		}
+	}
+
+	if diagnostics := cyclonedx.Validate(content); cyclonedx.HasErrors(diagnostics) {
+		return &BOMValidationError{Diagnostics: diagnostics}
+	}
+
+	return nil
+}
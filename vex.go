@@ -31,12 +31,20 @@ func (vs VEXService) ExportCycloneDX(ctx context.Context, projectUUID uuid.UUID)
 	return
 }
 
-func (vs VEXService) Upload(ctx context.Context, uploadReq VEXUploadRequest) (err error) {
+// Upload uploads a VEX document. The returned token can be used with EventService
+// to wait for the VEX to be processed.
+func (vs VEXService) Upload(ctx context.Context, uploadReq VEXUploadRequest) (token EventToken, err error) {
 	req, err := vs.client.newRequest(ctx, http.MethodPut, "/api/v1/vex", withBody(uploadReq))
 	if err != nil {
 		return
 	}
 
-	_, err = vs.client.doRequest(req, nil)
+	var uploadRes eventTokenResponse
+	_, err = vs.client.doRequest(req, &uploadRes)
+	if err != nil {
+		return
+	}
+
+	token = uploadRes.Token
 	return
 }
@@ -3,6 +3,7 @@ package dtrack
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strconv"
 
@@ -94,6 +95,13 @@ func (vs VulnerabilityService) GetAllForComponent(ctx context.Context, component
 	return
 }
 
+// AllForComponent returns an iterator over all items that GetAllForComponent would return across all pages.
+func (vs VulnerabilityService) AllForComponent(ctx context.Context, componentUUID uuid.UUID, suppressed bool, options ...IterOption) iter.Seq2[Vulnerability, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Vulnerability], error) {
+		return vs.GetAllForComponent(ctx, componentUUID, suppressed, po)
+	}, options...)
+}
+
 func (vs VulnerabilityService) GetAllForProject(ctx context.Context, projectUUID uuid.UUID, suppressed bool, po PageOptions) (p Page[Vulnerability], err error) {
 	params := map[string]string{
 		"suppressed": strconv.FormatBool(suppressed),
@@ -113,6 +121,13 @@ func (vs VulnerabilityService) GetAllForProject(ctx context.Context, projectUUID
 	return
 }
 
+// AllForProject returns an iterator over all items that GetAllForProject would return across all pages.
+func (vs VulnerabilityService) AllForProject(ctx context.Context, projectUUID uuid.UUID, suppressed bool, options ...IterOption) iter.Seq2[Vulnerability, error] {
+	return Iterate(ctx, func(ctx context.Context, po PageOptions) (Page[Vulnerability], error) {
+		return vs.GetAllForProject(ctx, projectUUID, suppressed, po)
+	}, options...)
+}
+
 func (vs VulnerabilityService) Assign(ctx context.Context, vulnUUID, componentUUID uuid.UUID) (err error) {
 	req, err := vs.client.newRequest(ctx, http.MethodPost, fmt.Sprintf("/api/v1/vulnerability/%s/component/%s", vulnUUID, componentUUID))
 	if err != nil {
@@ -0,0 +1,116 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrProcessingTimeout is returned when processing did not complete within WaitOptions.Timeout.
+// Use errors.As with *ProcessingTimeoutError to learn more about the wait.
+var ErrProcessingTimeout = errors.New("processing timed out")
+
+// ProcessingTimeoutError is returned when processing did not complete within WaitOptions.Timeout.
+type ProcessingTimeoutError struct {
+	Token   string
+	Polls   int
+	Elapsed time.Duration
+}
+
+func (e ProcessingTimeoutError) Error() string {
+	return fmt.Sprintf("processing of token %s did not complete within %s (%d polls)", e.Token, e.Elapsed.Round(time.Millisecond), e.Polls)
+}
+
+func (e ProcessingTimeoutError) Is(target error) bool {
+	return target == ErrProcessingTimeout
+}
+
+// WaitOptions configures how to wait for the processing of a token to complete.
+type WaitOptions struct {
+	PollInterval    time.Duration        // Interval between polls. Defaults to 1s.
+	MaxPollInterval time.Duration        // Upper bound of the interval when backing off. Defaults to 30s.
+	Multiplier      float64              // Factor the interval grows by after each poll. Defaults to 1 (no backoff).
+	Timeout         time.Duration        // Overall time to wait before giving up. Zero means no timeout besides ctx.
+	OnProgress      func(p WaitProgress) // Called after every poll, if not nil.
+}
+
+// WaitProgress describes the state of a wait after a poll.
+type WaitProgress struct {
+	Polls      int
+	Elapsed    time.Duration
+	Processing bool
+}
+
+func (wo WaitOptions) withDefaults() WaitOptions {
+	if wo.PollInterval <= 0 {
+		wo.PollInterval = time.Second
+	}
+	if wo.MaxPollInterval <= 0 {
+		wo.MaxPollInterval = 30 * time.Second
+	}
+	if wo.MaxPollInterval < wo.PollInterval {
+		wo.MaxPollInterval = wo.PollInterval
+	}
+	if wo.Multiplier < 1 {
+		wo.Multiplier = 1
+	}
+
+	return wo
+}
+
+// waitForProcessing polls isProcessing until it reports that processing of token completed.
+func waitForProcessing(ctx context.Context, token string, opts WaitOptions, isProcessing func(ctx context.Context) (bool, error)) error {
+	opts = opts.withDefaults()
+	start := time.Now()
+
+	waitCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	// timedOut reports whether waitCtx expired because of opts.Timeout, rather than ctx being done.
+	timedOut := func() bool {
+		return ctx.Err() == nil && waitCtx.Err() != nil
+	}
+
+	interval := opts.PollInterval
+	for polls := 1; ; polls++ {
+		processing, err := isProcessing(waitCtx)
+		if err != nil {
+			if timedOut() {
+				return &ProcessingTimeoutError{Token: token, Polls: polls, Elapsed: time.Since(start)}
+			}
+			return err
+		}
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(WaitProgress{
+				Polls:      polls,
+				Elapsed:    time.Since(start),
+				Processing: processing,
+			})
+		}
+		if !processing {
+			return nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-waitCtx.Done():
+			timer.Stop()
+			if timedOut() {
+				return &ProcessingTimeoutError{Token: token, Polls: polls, Elapsed: time.Since(start)}
+			}
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * opts.Multiplier)
+		if interval > opts.MaxPollInterval {
+			interval = opts.MaxPollInterval
+		}
+	}
+}
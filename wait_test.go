@@ -0,0 +1,145 @@
+package dtrack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBOMService_WaitForProcessing(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	t.Run("Completes", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/bom/token/foo",
+			httpmock.NewStringResponder(http.StatusOK, `{"processing":true}`).
+				Then(httpmock.NewStringResponder(http.StatusOK, `{"processing":true}`)).
+				Then(httpmock.NewStringResponder(http.StatusOK, `{"processing":false}`)))
+
+		var progress []WaitProgress
+		err := client.BOM.WaitForProcessing(context.TODO(), "foo", WaitOptions{
+			PollInterval: time.Millisecond,
+			Multiplier:   2,
+			OnProgress: func(p WaitProgress) {
+				progress = append(progress, p)
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, progress, 3)
+		require.True(t, progress[0].Processing)
+		require.Equal(t, 3, progress[2].Polls)
+		require.False(t, progress[2].Processing)
+	})
+
+	t.Run("TimesOut", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/bom/token/foo",
+			httpmock.NewStringResponder(http.StatusOK, `{"processing":true}`))
+
+		err := client.BOM.WaitForProcessing(context.TODO(), "foo", WaitOptions{
+			PollInterval: 5 * time.Millisecond,
+			Timeout:      20 * time.Millisecond,
+		})
+		require.ErrorIs(t, err, ErrProcessingTimeout)
+
+		var timeoutErr *ProcessingTimeoutError
+		require.True(t, errors.As(err, &timeoutErr))
+		require.Equal(t, "foo", timeoutErr.Token)
+		require.Positive(t, timeoutErr.Polls)
+	})
+
+	t.Run("ContextCancelled", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/bom/token/foo",
+			httpmock.NewStringResponder(http.StatusOK, `{"processing":true}`))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := client.BOM.WaitForProcessing(ctx, "foo", WaitOptions{
+			PollInterval: 5 * time.Millisecond,
+			Timeout:      time.Minute,
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.NotErrorIs(t, err, ErrProcessingTimeout)
+	})
+}
+
+func TestBOMService_WaitForProcessing_ServerVersion(t *testing.T) {
+	for _, tc := range []struct {
+		version string
+		path    string
+	}{
+		{"4.11.0", "/api/v1/event/token/foo"},
+		{"4.10.1", "/api/v1/bom/token/foo"},
+	} {
+		t.Run(tc.version, func(t *testing.T) {
+			client, err := NewClient("http://localhost")
+			require.NoError(t, err)
+
+			httpmock.ActivateNonDefault(client.httpClient)
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/version",
+				httpmock.NewStringResponder(http.StatusOK, `{"version":"`+tc.version+`"}`))
+			httpmock.RegisterResponder(http.MethodGet, "http://localhost"+tc.path,
+				httpmock.NewStringResponder(http.StatusOK, `{"processing":true}`).
+					Then(httpmock.NewStringResponder(http.StatusOK, `{"processing":false}`)))
+
+			err = client.BOM.WaitForProcessing(context.TODO(), "foo", WaitOptions{PollInterval: time.Millisecond})
+			require.NoError(t, err)
+			require.Equal(t, 2, httpmock.GetCallCountInfo()["GET http://localhost"+tc.path])
+		})
+	}
+}
+
+func TestWaitOptions_WithDefaults(t *testing.T) {
+	opts := WaitOptions{PollInterval: time.Second, MaxPollInterval: 500 * time.Millisecond, Multiplier: 0.5}.withDefaults()
+	require.Equal(t, time.Second, opts.MaxPollInterval)
+	require.Equal(t, float64(1), opts.Multiplier)
+}
+
+func TestBOMService_UploadAndWait(t *testing.T) {
+	client, err := NewClient("http://localhost")
+	require.NoError(t, err)
+
+	httpmock.ActivateNonDefault(client.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	projectUUID := "6fb1820f-5280-4577-ac51-40124aabe307"
+
+	httpmock.RegisterResponder(http.MethodPut, "http://localhost/api/v1/bom",
+		httpmock.NewStringResponder(http.StatusOK, `{"token":"foo"}`))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/bom/token/foo",
+		httpmock.NewStringResponder(http.StatusOK, `{"processing":true}`).
+			Then(httpmock.NewStringResponder(http.StatusOK, `{"processing":false}`)))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/project/lookup?name=acme-app&version=1.0.0",
+		httpmock.NewStringResponder(http.StatusOK, `{"uuid":"`+projectUUID+`","name":"acme-app","version":"1.0.0"}`))
+	httpmock.RegisterResponder(http.MethodGet, "http://localhost/api/v1/metrics/project/"+projectUUID+"/refresh",
+		httpmock.NewStringResponder(http.StatusOK, ""))
+
+	project, err := client.BOM.UploadAndWait(context.TODO(), BOMUploadRequest{
+		ProjectName:    "acme-app",
+		ProjectVersion: "1.0.0",
+		AutoCreate:     true,
+		BOM:            "Ym9t",
+	}, UploadAndWaitOptions{
+		Wait:           WaitOptions{PollInterval: time.Millisecond},
+		RefreshMetrics: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, projectUUID, project.UUID.String())
+
+	callCount := httpmock.GetCallCountInfo()
+	require.Equal(t, 2, callCount["GET http://localhost/api/v1/bom/token/foo"])
+	require.Equal(t, 1, callCount["GET http://localhost/api/v1/metrics/project/"+projectUUID+"/refresh"])
+}